@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"github.com/viant/sqlx/metadata/info"
+	"time"
+)
+
+// Operation represents audited operation
+type Operation string
+
+const (
+	//OpInsert represents insert operation
+	OpInsert = Operation("insert")
+	//OpUpdate represents update operation
+	OpUpdate = Operation("update")
+	//OpDelete represents delete operation
+	OpDelete = Operation("delete")
+)
+
+type (
+	// Change represents audited row change
+	Change struct {
+		Table     string
+		Operation Operation
+		Actor     string
+		Timestamp time.Time
+		Key       map[string]interface{}
+		Before    map[string]interface{} //nil for inserted row
+		After     map[string]interface{} //nil for deleted row
+		Columns   []string               //changed columns
+	}
+
+	// DB represents transaction or database used to capture row images and write changes
+	DB interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	}
+
+	// Sink represents change records sink, services call it within write transaction before commit
+	Sink interface {
+		Write(ctx context.Context, db DB, dialect *info.Dialect, changes []*Change) error
+	}
+
+	// Func represents callback sink
+	Func func(ctx context.Context, changes []*Change) error
+
+	// Trail represents audit trail option capturing before and after row images for update, delete and merge services
+	Trail struct {
+		Sink Sink
+		Now  func() time.Time
+	}
+)
+
+// Write calls callback with changes
+func (f Func) Write(ctx context.Context, db DB, dialect *info.Dialect, changes []*Change) error {
+	return f(ctx, changes)
+}
+
+type actorKey struct{}
+
+// WithActor returns context carrying audit actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns context audit actor
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// New creates audit trail writing change records to sink
+func New(sink Sink) *Trail {
+	return &Trail{Sink: sink, Now: time.Now}
+}
@@ -0,0 +1,215 @@
+package audit_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/audit"
+	"github.com/viant/sqlx/io/delete"
+	"github.com/viant/sqlx/io/update"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/option"
+	"path"
+	"testing"
+	"time"
+)
+
+type account struct {
+	ID      int    `sqlx:"id,primaryKey"`
+	Name    string `sqlx:"name"`
+	Balance int    `sqlx:"balance"`
+}
+
+func TestTrail(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var testCases = []struct {
+		description string
+		exec        func(ctx context.Context, db *sql.DB, trail *audit.Trail) (int64, error)
+		expect      []*audit.Change
+	}{
+		{
+			description: "update",
+			exec: func(ctx context.Context, db *sql.DB, trail *audit.Trail) (int64, error) {
+				updater, err := update.New(ctx, db, "accounts")
+				if err != nil {
+					return 0, err
+				}
+				return updater.Exec(ctx, []*account{{ID: 1, Name: "alice", Balance: 150}, {ID: 3, Name: "missing"}}, trail)
+			},
+			expect: []*audit.Change{
+				{
+					Table: "accounts", Operation: audit.OpUpdate, Actor: "auditor", Timestamp: now,
+					Key:     map[string]interface{}{"id": 1},
+					Before:  map[string]interface{}{"id": int64(1), "name": "alice", "balance": int64(100)},
+					After:   map[string]interface{}{"id": int64(1), "name": "alice", "balance": int64(150)},
+					Columns: []string{"balance"},
+				},
+			},
+		},
+		{
+			description: "delete",
+			exec: func(ctx context.Context, db *sql.DB, trail *audit.Trail) (int64, error) {
+				deleter, err := delete.New(ctx, db, "accounts")
+				if err != nil {
+					return 0, err
+				}
+				return deleter.Exec(ctx, []*account{{ID: 2}, {ID: 3}}, trail)
+			},
+			expect: []*audit.Change{
+				{
+					Table: "accounts", Operation: audit.OpDelete, Actor: "auditor", Timestamp: now,
+					Key:    map[string]interface{}{"id": 2},
+					Before: map[string]interface{}{"id": int64(2), "name": "bob", "balance": int64(200)},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "audit.db"))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, SQL := range []string{
+			"CREATE TABLE accounts (id INTEGER PRIMARY KEY, name TEXT, balance INTEGER)",
+			"CREATE TABLE audit_log (TABLE_NAME TEXT, OPERATION TEXT, ACTOR TEXT, CREATED TIMESTAMP, ROW_KEY TEXT, BEFORE_IMAGE TEXT, AFTER_IMAGE TEXT, CHANGED_COLUMNS TEXT)",
+			"INSERT INTO accounts VALUES (1, 'alice', 100), (2, 'bob', 200)",
+		} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testCase.description)
+		}
+		var actual []*audit.Change
+		table := audit.NewTable("audit_log")
+		trail := audit.New(audit.Func(func(ctx context.Context, changes []*audit.Change) error {
+			actual = append(actual, changes...)
+			return nil
+		}))
+		trail.Now = func() time.Time { return now }
+		ctx := audit.WithActor(context.Background(), "auditor")
+		_, err = testCase.exec(ctx, db, trail)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+
+		trail.Sink = table
+		_, err = db.Exec("INSERT OR REPLACE INTO accounts VALUES (1, 'alice', 100), (2, 'bob', 200)")
+		assert.Nil(t, err, testCase.description)
+		_, err = testCase.exec(ctx, db, trail)
+		assert.Nil(t, err, testCase.description)
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM audit_log WHERE OPERATION = ? AND ACTOR = ?", string(testCase.expect[0].Operation), "auditor").Scan(&count)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, len(testCase.expect), count, testCase.description)
+		_ = db.Close()
+	}
+}
+
+func TestRecorder_RollbackOnSinkError(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "audit.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, name TEXT, balance INTEGER)")
+	assert.Nil(t, err)
+	_, err = db.Exec("INSERT INTO accounts VALUES (1, 'alice', 100)")
+	assert.Nil(t, err)
+	updater, err := update.New(context.Background(), db, "accounts")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = updater.Exec(context.Background(), []*account{{ID: 1, Name: "alice", Balance: 0}}, audit.New(audit.NewTable("missing_audit_log")), option.BatchSize(1))
+	assert.NotNil(t, err)
+	var balance int
+	assert.Nil(t, db.QueryRow("SELECT balance FROM accounts WHERE id = 1").Scan(&balance))
+	assert.EqualValues(t, 100, balance)
+}
+
+type countingDB struct {
+	*sql.DB
+	queries int
+}
+
+func (d *countingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	d.queries++
+	return d.DB.QueryContext(ctx, query, args...)
+}
+
+func TestRecorder_FetchAll(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "fetch.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE items (id INTEGER, region TEXT, name TEXT, PRIMARY KEY(id, region))")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for i := 1; i <= 300; i++ {
+		_, err = db.Exec("INSERT INTO items VALUES (?, 'us', ?)", i, "name")
+		assert.Nil(t, err)
+	}
+
+	var testCases = []struct {
+		description   string
+		keys          []string
+		values        func(i int) []interface{}
+		expectID      func(i int) int64
+		count         int
+		expectQueries int
+		expectNil     []int
+	}{
+		{
+			description:   "single key batched",
+			keys:          []string{"id"},
+			values:        func(i int) []interface{} { id := i + 1; return []interface{}{&id} },
+			expectID:      func(i int) int64 { return int64(i + 1) },
+			count:         302,
+			expectQueries: 2,
+			expectNil:     []int{300, 301},
+		},
+		{
+			description:   "composite key",
+			keys:          []string{"ID", "region"},
+			values:        func(i int) []interface{} { return []interface{}{i % 3, "us"} },
+			expectID:      func(i int) int64 { return int64(i % 3) },
+			count:         4,
+			expectQueries: 1,
+			expectNil:     []int{0, 3},
+		},
+	}
+	for _, testCase := range testCases {
+		counter := &countingDB{DB: db}
+		recorder := audit.New(nil).Recorder("items", testCase.keys, nil, counter)
+		var keys [][]interface{}
+		for i := 0; i < testCase.count; i++ {
+			keys = append(keys, testCase.values(i))
+		}
+		images, err := recorder.FetchAll(context.Background(), keys)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectQueries, counter.queries, testCase.description)
+		if !assert.Len(t, images, testCase.count, testCase.description) {
+			continue
+		}
+		for i, image := range images {
+			if contains(testCase.expectNil, i) {
+				assert.Nil(t, image, testCase.description)
+				continue
+			}
+			if assert.NotNil(t, image, testCase.description) {
+				assert.EqualValues(t, testCase.expectID(i), image["id"], testCase.description)
+			}
+		}
+	}
+}
+
+func contains(values []int, value int) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata/info"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Recorder captures row images and changes of a single service execution, nil recorder is a no-op
+type Recorder struct {
+	trail   *Trail
+	table   string
+	keys    []string
+	dialect *info.Dialect
+	db      DB
+	SQL     string
+	changes []*Change
+}
+
+// Recorder creates change recorder fetching row images by key columns with supplied transaction or database
+func (t *Trail) Recorder(table string, keys []string, dialect *info.Dialect, db DB) *Recorder {
+	if t == nil {
+		return nil
+	}
+	criteria := make([]string, len(keys))
+	for i, key := range keys {
+		criteria[i] = key + " = ?"
+	}
+	SQL := "SELECT * FROM " + table + " WHERE " + strings.Join(criteria, " AND ")
+	if dialect != nil {
+		SQL = dialect.EnsurePlaceholders(SQL)
+	}
+	return &Recorder{trail: t, table: table, keys: keys, dialect: dialect, db: db, SQL: SQL}
+}
+
+// fetchBatchSize represents max number of keys fetched with one query
+const fetchBatchSize = 256
+
+// Fetch returns row image for key values or nil if row does not exist
+func (r *Recorder) Fetch(ctx context.Context, key []interface{}) (map[string]interface{}, error) {
+	if r == nil {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, r.SQL, key...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %v audit image: %w", r.table, err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	image, err := r.scanImage(rows, columns)
+	if err != nil {
+		return nil, err
+	}
+	return image, rows.Err()
+}
+
+// FetchAll returns row images for keys (nil image if row does not exist), images are fetched with one query per keys batch
+func (r *Recorder) FetchAll(ctx context.Context, keys [][]interface{}) ([]map[string]interface{}, error) {
+	if r == nil {
+		return nil, nil
+	}
+	var result = make([]map[string]interface{}, len(keys))
+	for offset := 0; offset < len(keys); offset += fetchBatchSize {
+		end := offset + fetchBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := r.fetchBatch(ctx, keys[offset:end], result[offset:end]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *Recorder) fetchBatch(ctx context.Context, keys [][]interface{}, images []map[string]interface{}) error {
+	var positions = make(map[string][]int, len(keys))
+	var args = make([]interface{}, 0, len(keys)*len(r.keys))
+	for i, key := range keys {
+		id := keyID(key)
+		if _, ok := positions[id]; !ok {
+			args = append(args, key...)
+		}
+		positions[id] = append(positions[id], i)
+	}
+	SQL := r.batchSQL(len(args) / len(r.keys))
+	rows, err := r.db.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %v audit images: %w", r.table, err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	keyColumns, err := r.keyColumns(columns)
+	if err != nil {
+		return err
+	}
+	var key = make([]interface{}, len(keyColumns))
+	for rows.Next() {
+		image, err := r.scanImage(rows, columns)
+		if err != nil {
+			return err
+		}
+		for i, column := range keyColumns {
+			key[i] = image[column]
+		}
+		for _, position := range positions[keyID(key)] {
+			images[position] = image
+		}
+	}
+	return rows.Err()
+}
+
+// batchSQL returns image SQL for keys count, single key column uses IN criteria
+func (r *Recorder) batchSQL(count int) string {
+	sb := strings.Builder{}
+	sb.WriteString("SELECT * FROM ")
+	sb.WriteString(r.table)
+	sb.WriteString(" WHERE ")
+	if len(r.keys) == 1 {
+		sb.WriteString(r.keys[0])
+		sb.WriteString(" IN (")
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("?")
+		}
+		sb.WriteString(")")
+	} else {
+		criteria := make([]string, len(r.keys))
+		for i, key := range r.keys {
+			criteria[i] = key + " = ?"
+		}
+		conjunction := "(" + strings.Join(criteria, " AND ") + ")"
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				sb.WriteString(" OR ")
+			}
+			sb.WriteString(conjunction)
+		}
+	}
+	SQL := sb.String()
+	if r.dialect != nil {
+		SQL = r.dialect.EnsurePlaceholders(SQL)
+	}
+	return SQL
+}
+
+// keyColumns returns image column names matching key columns
+func (r *Recorder) keyColumns(columns []string) ([]string, error) {
+	var result = make([]string, len(r.keys))
+	for i, key := range r.keys {
+		for _, column := range columns {
+			if strings.EqualFold(key, column) {
+				result[i] = column
+				break
+			}
+		}
+		if result[i] == "" {
+			return nil, fmt.Errorf("failed to match %v audit key column: %v", r.table, key)
+		}
+	}
+	return result, nil
+}
+
+func (r *Recorder) scanImage(rows *sql.Rows, columns []string) (map[string]interface{}, error) {
+	var values = make([]interface{}, len(columns))
+	var pointers = make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, fmt.Errorf("failed to scan %v audit image: %w", r.table, err)
+	}
+	var image = make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if data, ok := values[i].([]byte); ok {
+			values[i] = string(data)
+		}
+		image[column] = values[i]
+	}
+	return image, nil
+}
+
+// Add records change with context actor, changed columns are derived from images if nil
+func (r *Recorder) Add(ctx context.Context, op Operation, key []interface{}, before, after map[string]interface{}, columns []string) {
+	if r == nil {
+		return
+	}
+	if columns == nil && op == OpUpdate {
+		columns = Diff(before, after)
+	}
+	change := &Change{
+		Table:     r.table,
+		Operation: op,
+		Actor:     Actor(ctx),
+		Timestamp: r.trail.now(),
+		Key:       make(map[string]interface{}, len(r.keys)),
+		Before:    before,
+		After:     after,
+		Columns:   columns,
+	}
+	for i, name := range r.keys {
+		change.Key[name] = dereference(key[i])
+	}
+	r.changes = append(r.changes, change)
+}
+
+// Changes returns recorded changes
+func (r *Recorder) Changes() []*Change {
+	if r == nil {
+		return nil
+	}
+	return r.changes
+}
+
+// Flush writes recorded changes to trail sink
+func (r *Recorder) Flush(ctx context.Context) error {
+	if r == nil || len(r.changes) == 0 || r.trail.Sink == nil {
+		return nil
+	}
+	err := r.trail.Sink.Write(ctx, r.db, r.dialect, r.changes)
+	r.changes = nil
+	return err
+}
+
+// Diff returns sorted names of columns with different before and after values
+func Diff(before, after map[string]interface{}) []string {
+	var result []string
+	for name, value := range after {
+		if prev, ok := before[name]; !ok || !reflect.DeepEqual(prev, value) {
+			result = append(result, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// keyID returns key values identity, driver scanned values and binder placeholders of the same key share identity
+func keyID(key []interface{}) string {
+	sb := strings.Builder{}
+	for i, value := range key {
+		if i > 0 {
+			sb.WriteByte(0)
+		}
+		sb.WriteString(fmt.Sprint(dereference(value)))
+	}
+	return sb.String()
+}
+
+// dereference returns value pointed by binder placeholder
+func dereference(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if rValue := reflect.ValueOf(value); rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return nil
+		}
+		return rValue.Elem().Interface()
+	}
+	return value
+}
+
+func (t *Trail) now() time.Time {
+	if t.Now == nil {
+		return time.Now()
+	}
+	return t.Now()
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/sqlx/metadata/info"
+	"strings"
+)
+
+// TableColumns represents audit table columns
+var TableColumns = []string{"TABLE_NAME", "OPERATION", "ACTOR", "CREATED", "ROW_KEY", "BEFORE_IMAGE", "AFTER_IMAGE", "CHANGED_COLUMNS"}
+
+// Table represents audit table sink, row key, images and changed columns are stored as JSON text
+type Table struct {
+	Name string
+}
+
+// Write inserts change records into audit table
+func (t *Table) Write(ctx context.Context, db DB, dialect *info.Dialect, changes []*Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	SQL := "INSERT INTO " + t.Name + "(" + strings.Join(TableColumns, ",") + ") VALUES (" + strings.TrimSuffix(strings.Repeat("?,", len(TableColumns)), ",") + ")"
+	if dialect != nil {
+		SQL = dialect.EnsurePlaceholders(SQL)
+	}
+	for _, change := range changes {
+		args := []interface{}{change.Table, string(change.Operation), change.Actor, change.Timestamp}
+		for _, value := range []interface{}{change.Key, change.Before, change.After, change.Columns} {
+			encoded, err := encode(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode %v audit record: %w", change.Table, err)
+			}
+			args = append(args, encoded)
+		}
+		if _, err := db.ExecContext(ctx, SQL, args...); err != nil {
+			return fmt.Errorf("failed to write %v audit record: %w", change.Table, err)
+		}
+	}
+	return nil
+}
+
+func encode(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		if actual == nil {
+			return nil, nil
+		}
+	case []string:
+		if actual == nil {
+			return nil, nil
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// NewTable creates audit table sink
+func NewTable(name string) *Table {
+	return &Table{Name: name}
+}
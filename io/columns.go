@@ -56,6 +56,16 @@ func (c Columns) PrimaryKeys() int {
 	return -1
 }
 
+// Tenant returns tenant column index or -1
+func (c Columns) Tenant() int {
+	for i, item := range c {
+		if tag := item.Tag(); tag != nil && tag.Tenant {
+			return i
+		}
+	}
+	return -1
+}
+
 // Names returns column names
 func (c Columns) Names() []string {
 	var result = make([]string, len(c))
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/sqlx/option"
+)
+
+//CheckConstraints returns table check constraints
+func CheckConstraints(ctx context.Context, session *sink.Session, db *sql.DB, table string, options ...option.Option) ([]sink.Check, error) {
+	meta := metadata.New()
+	checks := make([]sink.Check, 0)
+	if options == nil {
+		options = make(option.Options, 0)
+	}
+	options = append(options, option.NewArgs(session.Catalog, session.Schema, table))
+	err := meta.Info(ctx, db, info.KindCheckConstraints, &checks, options...)
+	return checks, err
+}
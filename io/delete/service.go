@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/io/outbox"
+	"github.com/viant/sqlx/io/retry"
+	"github.com/viant/sqlx/io/router"
 	"github.com/viant/sqlx/option"
 	"reflect"
 	"sync"
@@ -16,10 +20,32 @@ type Service struct {
 	initSession *session
 	mux         sync.Mutex
 	db          *sql.DB
+	shards      sync.Map
 }
 
-// Exec runs delete statements
+// Exec runs delete statements, audit.Trail option captures pre-images of deleted rows within delete transaction,
+// retry policy option re-runs the whole service owned transaction on transient failure
+// option.ShardResolver splits records by shard and executes shard groups in parallel,
+// outbox.Outbox option appends events of written records within the same transaction
 func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.Option) (int64, error) {
+	ctx = router.WithPrimary(ctx)
+	if resolver := option.Options(options).ShardResolver(); resolver != nil {
+		return s.execShards(ctx, resolver, any, options...)
+	}
+	policy := retry.Owned(options)
+	if policy == nil {
+		return s.exec(ctx, any, options...)
+	}
+	var rowsAffected int64
+	labels := metrics.Labels{Source: "delete", Table: s.TableName, Op: "exec"}
+	err := policy.RunObserved(ctx, option.Options(options).Collector(), labels, func(ctx context.Context) (err error) {
+		rowsAffected, err = s.exec(ctx, any, options...)
+		return err
+	})
+	return rowsAffected, err
+}
+
+func (s *Service) exec(ctx context.Context, any interface{}, options ...option.Option) (int64, error) {
 	recordsFn, cnt, err := io.Iterator(any)
 	if cnt == 0 {
 		return 0, nil
@@ -44,6 +70,14 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 	}
 
 	rowsAffected, err := sess.delete(ctx, record, recordsFn, batchSize)
+	if err == nil {
+		err = sess.recorder.Flush(ctx)
+	}
+	if err == nil {
+		var events *outbox.Outbox
+		option.Assign(options, &events)
+		err = events.Append(ctx, sess.Transaction, s.db, sess.Dialect, s.TableName, outbox.OpDelete, any)
+	}
 	err = sess.end(err)
 	return rowsAffected, err
 
@@ -5,7 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/audit"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/tenant"
 	"github.com/viant/sqlx/option"
 	"reflect"
 )
@@ -20,6 +23,9 @@ type session struct {
 	transactional bool
 	db            *sql.DB
 	stmt          *sql.Stmt
+	SQL           string
+	hooks         *hook.Chain
+	recorder      *audit.Recorder
 }
 
 func (s *session) init(record interface{}) (err error) {
@@ -30,7 +36,11 @@ func (s *session) init(record interface{}) (err error) {
 	if s.columns, s.binder, err = s.Mapper(record, option.IdentityOnly(true)); err != nil {
 		return err
 	}
-	recordlessBuilder, err := NewBuilder(s.TableName, s.columns.Names(), s.Dialect, s.batchSize)
+	tenantColumn := ""
+	if tenantIndex := s.columns.Tenant(); tenantIndex != -1 {
+		tenantColumn = s.columns[tenantIndex].Name()
+	}
+	recordlessBuilder, err := newBuilder(s.TableName, s.columns[:s.keyColumns()].Names(), tenantColumn, s.Dialect, s.batchSize)
 	if err != nil {
 		return err
 	}
@@ -41,11 +51,23 @@ func (s *session) init(record interface{}) (err error) {
 
 func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
 	var err error
+	s.hooks = sqlHooks(options)
 	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
 	if err != nil {
 		return err
 	}
-
+	var trail *audit.Trail
+	if option.Assign(options, &trail) {
+		var auditDB audit.DB = db
+		if s.Transaction != nil {
+			auditDB = s.Transaction.Tx
+		}
+		keys := s.columns[:s.keyColumns()].Names()
+		if tenantIndex := s.columns.Tenant(); tenantIndex != -1 {
+			keys = append(keys, s.columns[tenantIndex].Name())
+		}
+		s.recorder = trail.Recorder(s.TableName, keys, s.Dialect, auditDB)
+	}
 	return nil
 }
 
@@ -57,25 +79,43 @@ func (s *session) prepare(ctx context.Context, batchSize int) error {
 			return fmt.Errorf("failed to close stetement: %w", err)
 		}
 	}
-	if showSQL {
-		fmt.Println(SQL)
-	}
+	s.SQL = SQL
+	ctx, done := s.hooks.Start(ctx, s.event(hook.OpPrepare, nil))
 	if s.Transaction != nil {
 		s.stmt, err = s.Transaction.Prepare(SQL)
-		return err
+	} else {
+		s.stmt, err = s.db.PrepareContext(ctx, SQL)
 	}
-	s.stmt, err = s.db.PrepareContext(ctx, SQL)
+	done(0, err)
 	return err
 }
 
+func (s *session) event(op hook.Operation, args []interface{}) *hook.Event {
+	return &hook.Event{Op: op, Source: "delete", Table: s.TableName, SQL: s.SQL, Args: args}
+}
+
 func (s *session) delete(ctx context.Context, record interface{}, recordsFn func() interface{}, batchSize int) (int64, error) {
-	var recValues = make([]interface{}, batchSize*len(s.columns))
+	keyColumns := s.keyColumns()
+	var recValues = make([]interface{}, 1+batchSize*keyColumns)
+	if s.columns.Tenant() != -1 { //tenant predicate precedes keys
+		tenantID, err := tenant.RequiredID(ctx)
+		if err != nil {
+			return 0, err
+		}
+		recValues[0] = tenantID
+	} else {
+		recValues = recValues[1:]
+	}
+	keyOffset := len(recValues) - batchSize*keyColumns
 	totalRowsAffected := int64(0)
 	inBatchCount := 0
 
 	for ; record != nil; record = recordsFn() {
-		offset := inBatchCount * len(s.columns)
-		s.binder(record, recValues[offset:], 0, len(s.columns))
+		offset := keyOffset + inBatchCount*keyColumns
+		s.binder(record, recValues[offset:], 0, keyColumns)
+		if err := s.capture(ctx, recValues[offset:offset+keyColumns], recValues[:keyOffset]); err != nil {
+			return 0, err
+		}
 		inBatchCount++
 		if inBatchCount == batchSize {
 			rowsAffected, err := s.flush(ctx, recValues)
@@ -92,7 +132,7 @@ func (s *session) delete(ctx context.Context, record interface{}, recordsFn func
 		if err != nil {
 			return 0, nil
 		}
-		rowsAffected, err := s.flush(ctx, recValues[0:inBatchCount*len(s.columns)])
+		rowsAffected, err := s.flush(ctx, recValues[0:keyOffset+inBatchCount*keyColumns])
 		if err != nil {
 			return 0, nil
 		}
@@ -101,6 +141,28 @@ func (s *session) delete(ctx context.Context, record interface{}, recordsFn func
 	return totalRowsAffected, nil
 }
 
+// capture records pre-image of deleted row
+func (s *session) capture(ctx context.Context, keyValues []interface{}, tenantValues []interface{}) error {
+	if s.recorder == nil {
+		return nil
+	}
+	key := append(append([]interface{}{}, keyValues...), tenantValues...)
+	before, err := s.recorder.Fetch(ctx, key)
+	if err != nil || before == nil {
+		return err
+	}
+	s.recorder.Add(ctx, audit.OpDelete, key, before, nil, nil)
+	return nil
+}
+
+// keyColumns returns number of key columns, tenant column follows key columns
+func (s *session) keyColumns() int {
+	if tenantIndex := s.columns.Tenant(); tenantIndex != -1 {
+		return tenantIndex
+	}
+	return len(s.columns)
+}
+
 func (s *session) end(err error) error {
 	if s.stmt != nil {
 		if sErr := s.stmt.Close(); sErr != nil {
@@ -120,11 +182,14 @@ func (s *session) end(err error) error {
 }
 
 func (s *session) flush(ctx context.Context, values []interface{}) (int64, error) {
+	ctx, done := s.hooks.Start(ctx, s.event(hook.OpExec, values))
 	result, err := s.stmt.ExecContext(ctx, values...)
 	if err != nil {
+		done(0, err)
 		return 0, err
 	}
 	rowsAffected, err := result.RowsAffected()
+	done(rowsAffected, err)
 	if err != nil {
 		return 0, err
 	}
@@ -2,8 +2,10 @@ package delete
 
 import (
 	"fmt"
+	"github.com/viant/sqlx/io/hook"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
+	"os"
 	"strings"
 )
 
@@ -38,10 +40,19 @@ func (b *Builder) Build(options ...option.Option) string {
 
 //NewBuilder return insert builder
 func NewBuilder(table string, columns []string, dialect *info.Dialect, batchSize int) (*Builder, error) {
+	return newBuilder(table, columns, "", dialect, batchSize)
+}
+
+// newBuilder return delete builder, non empty tenant column adds leading tenant predicate
+func newBuilder(table string, columns []string, tenantColumn string, dialect *info.Dialect, batchSize int) (*Builder, error) {
 	if len(columns) == 0 {
 		return nil, fmt.Errorf("columns were empty")
 	}
 	getter := dialect.PlaceholderGetter()
+	criteria := ""
+	if tenantColumn != "" {
+		criteria = tenantColumn + " = " + getter() + " AND "
+	}
 	multiColumn := len(columns) > 1
 	leftOp := strings.Join(columns, ",")
 	if multiColumn {
@@ -72,7 +83,7 @@ func NewBuilder(table string, columns []string, dialect *info.Dialect, batchSize
 	result := &Builder{
 		valueSize: itemSize,
 		batchSize: batchSize,
-		sql:       "DELETE FROM " + table + " WHERE " + leftOp + inFragment + rightOp.String() + ")",
+		sql:       "DELETE FROM " + table + " WHERE " + criteria + leftOp + inFragment + rightOp.String() + ")",
 	}
 	result.valuesOffset = strings.Index(result.sql, inFragment) + len(inFragment)
 	return result, nil
@@ -80,6 +91,19 @@ func NewBuilder(table string, columns []string, dialect *info.Dialect, batchSize
 
 var showSQL bool
 
+// ShowSQL prints prepared SQL to stdout when no hooks were supplied with options
+//
+// Deprecated: use hook.NewPrinter(os.Stdout) or hook.NewSlog(logger, level) option instead
 func ShowSQL(b bool) {
 	showSQL = b
 }
+
+func sqlHooks(options []option.Option) *hook.Chain {
+	if hooks := option.Options(options).Hooks(); hooks != nil {
+		return hooks
+	}
+	if showSQL {
+		return hook.New(hook.NewPrinter(os.Stdout))
+	}
+	return nil
+}
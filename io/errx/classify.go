@@ -0,0 +1,225 @@
+package errx
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrForeignKey indicates a foreign key constraint violation.
+	ErrForeignKey = errors.New("foreign key violation")
+
+	// ErrNotNull indicates a not null constraint violation.
+	ErrNotNull = errors.New("not null violation")
+
+	// ErrCheck indicates a check constraint violation.
+	ErrCheck = errors.New("check constraint violation")
+
+	// ErrDeadlock indicates transaction was chosen as a deadlock victim.
+	ErrDeadlock = errors.New("deadlock")
+
+	// ErrSerialization indicates transaction could not be serialized with concurrent transactions.
+	ErrSerialization = errors.New("serialization failure")
+
+	// ErrLockTimeout indicates lock wait timeout.
+	ErrLockTimeout = errors.New("lock timeout")
+
+	// ErrConnectionLost indicates broken database connection.
+	ErrConnectionLost = errors.New("connection lost")
+)
+
+type (
+	// Classification represents native driver error mapped into errx taxonomy
+	Classification struct {
+		Kind       error
+		Product    string
+		Code       string
+		Constraint string
+		Table      string
+		Column     string
+	}
+
+	// Classifier classifies native driver error, it returns nil for unrecognized error
+	Classifier func(err error) *Classification
+
+	classifier struct {
+		product  string
+		classify Classifier
+	}
+)
+
+var classifiers = struct {
+	items []*classifier
+	mux   sync.RWMutex
+}{}
+
+// RegisterClassifier registers product error classifier, registered classifier replaces one with the same product
+func RegisterClassifier(product string, classify Classifier) {
+	classifiers.mux.Lock()
+	defer classifiers.mux.Unlock()
+	for _, item := range classifiers.items {
+		if item.product == product {
+			item.classify = classify
+			return
+		}
+	}
+	classifiers.items = append(classifiers.items, &classifier{product: product, classify: classify})
+}
+
+// Classify classifies error with registered product classifiers, falling back to generic message matching,
+// it returns nil for unrecognized error
+func Classify(err error) *Classification {
+	if err == nil {
+		return nil
+	}
+	var sqlxErr *Error
+	if errors.As(err, &sqlxErr) && sqlxErr.Kind != nil && sqlxErr.Kind != ErrMissingIdentity {
+		return &Classification{Kind: sqlxErr.Kind, Code: sqlxErr.Code, Constraint: sqlxErr.Constraint, Table: sqlxErr.Table, Column: firstColumn(sqlxErr.Columns)}
+	}
+	classifiers.mux.RLock()
+	items := classifiers.items
+	classifiers.mux.RUnlock()
+	for _, item := range items {
+		if result := item.classify(err); result != nil {
+			if result.Product == "" {
+				result.Product = item.product
+			}
+			return result
+		}
+	}
+	return classifyMessage(err)
+}
+
+// ClassifySQLState returns errx kind for SQLSTATE code or nil
+func ClassifySQLState(state string) error {
+	switch state {
+	case "23505":
+		return ErrDuplicateKey
+	case "23503":
+		return ErrForeignKey
+	case "23502":
+		return ErrNotNull
+	case "23514":
+		return ErrCheck
+	case "40P01":
+		return ErrDeadlock
+	case "40001":
+		return ErrSerialization
+	case "55P03":
+		return ErrLockTimeout
+	case "57P01", "57P02", "57P03":
+		return ErrConnectionLost
+	}
+	if strings.HasPrefix(state, "08") {
+		return ErrConnectionLost
+	}
+	if strings.HasPrefix(state, "23") {
+		return ErrConstraint
+	}
+	return nil
+}
+
+var quotedName = regexp.MustCompile("[\"'`\\[]([^\"'`\\]]+)[\"'`\\]]")
+
+// QuotedName returns the first quoted name following marker in message, i.e. QuotedName(`constraint "uk_x"`, "constraint") returns uk_x
+func QuotedName(message, marker string) string {
+	index := strings.Index(strings.ToLower(message), strings.ToLower(marker))
+	if index == -1 {
+		return ""
+	}
+	match := quotedName.FindStringSubmatch(message[index+len(marker):])
+	if len(match) < 2 {
+		return ""
+	}
+	return match[1]
+}
+
+// IsForeignKey returns true if error is a foreign key violation
+func IsForeignKey(err error) bool { return isKind(err, ErrForeignKey) }
+
+// IsNotNull returns true if error is a not null violation
+func IsNotNull(err error) bool { return isKind(err, ErrNotNull) }
+
+// IsCheck returns true if error is a check constraint violation
+func IsCheck(err error) bool { return isKind(err, ErrCheck) }
+
+// IsDeadlock returns true if error is a deadlock
+func IsDeadlock(err error) bool { return isKind(err, ErrDeadlock) }
+
+// IsSerialization returns true if error is a serialization failure
+func IsSerialization(err error) bool { return isKind(err, ErrSerialization) }
+
+// IsLockTimeout returns true if error is a lock wait timeout
+func IsLockTimeout(err error) bool { return isKind(err, ErrLockTimeout) }
+
+// IsConnectionLost returns true if error is a broken connection
+func IsConnectionLost(err error) bool { return isKind(err, ErrConnectionLost) }
+
+// IsTransient returns true if operation failed with error that may succeed once retried:
+// deadlock, serialization failure, lock timeout or lost connection
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch kindOf(err) {
+	case ErrDeadlock, ErrSerialization, ErrLockTimeout, ErrConnectionLost:
+		return true
+	}
+	return false
+}
+
+func isKind(err error, kind error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, kind) {
+		return true
+	}
+	return kindOf(err) == kind
+}
+
+func kindOf(err error) error {
+	if classification := Classify(err); classification != nil {
+		return classification.Kind
+	}
+	return nil
+}
+
+func isConstraintKind(kind error) bool {
+	switch kind {
+	case ErrConstraint, ErrDuplicateKey, ErrForeignKey, ErrNotNull, ErrCheck:
+		return true
+	}
+	return false
+}
+
+func classifyMessage(err error) *Classification {
+	msg := strings.ToLower(errString(err))
+	switch {
+	case strings.Contains(msg, "unique constraint") ||
+		strings.Contains(msg, "duplicate key") ||
+		strings.Contains(msg, "duplicate entry"):
+		return &Classification{Kind: ErrDuplicateKey}
+	case strings.Contains(msg, "foreign key constraint"):
+		return &Classification{Kind: ErrForeignKey}
+	case strings.Contains(msg, "not null constraint"):
+		return &Classification{Kind: ErrNotNull}
+	case strings.Contains(msg, "check constraint"):
+		return &Classification{Kind: ErrCheck}
+	case strings.Contains(msg, "constraint failed") ||
+		strings.Contains(msg, "violates"):
+		return &Classification{Kind: ErrConstraint}
+	case strings.Contains(msg, "deadlock"):
+		return &Classification{Kind: ErrDeadlock}
+	}
+	return nil
+}
+
+func firstColumn(columns []string) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	return columns[0]
+}
@@ -0,0 +1,101 @@
+package errx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type codeError struct {
+	code    string
+	message string
+}
+
+func (e *codeError) Error() string { return e.message }
+
+func TestClassify(t *testing.T) {
+	RegisterClassifier("test", func(err error) *Classification {
+		var actual *codeError
+		if !errors.As(err, &actual) {
+			return nil
+		}
+		if kind := ClassifySQLState(actual.code); kind != nil {
+			return &Classification{Kind: kind, Code: actual.code, Constraint: QuotedName(actual.message, "constraint")}
+		}
+		return nil
+	})
+	var testCases = []struct {
+		description string
+		err         error
+		expectKind  error
+		expectCode  string
+		constraint  string
+		column      string
+		transient   bool
+	}{
+		{
+			description: "registered classifier",
+			err:         &codeError{code: "23503", message: `insert violates foreign key constraint "fk_user"`},
+			expectKind:  ErrForeignKey,
+			expectCode:  "23503",
+			constraint:  "fk_user",
+		},
+		{
+			description: "wrapped registered classifier error",
+			err:         fmt.Errorf("failed to flush: %w", &codeError{code: "40001", message: "could not serialize"}),
+			expectKind:  ErrSerialization,
+			expectCode:  "40001",
+			transient:   true,
+		},
+		{
+			description: "message fallback",
+			err:         errors.New("constraint failed: NOT NULL constraint failed: foo.bar (1299)"),
+			expectKind:  ErrNotNull,
+		},
+		{
+			description: "structured error",
+			err:         &Error{Kind: ErrCheck, Op: "insert", Table: "foo", Constraint: "ck_qty", Columns: []string{"qty"}},
+			expectKind:  ErrCheck,
+			constraint:  "ck_qty",
+			column:      "qty",
+		},
+		{
+			description: "unrecognized error",
+			err:         errors.New("syntax error"),
+		},
+	}
+	for _, testCase := range testCases {
+		actual := Classify(testCase.err)
+		assert.EqualValues(t, testCase.transient, IsTransient(testCase.err), testCase.description)
+		if testCase.expectKind == nil {
+			assert.Nil(t, actual, testCase.description)
+			continue
+		}
+		if !assert.NotNil(t, actual, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectKind, actual.Kind, testCase.description)
+		assert.EqualValues(t, testCase.expectCode, actual.Code, testCase.description)
+		assert.EqualValues(t, testCase.constraint, actual.Constraint, testCase.description)
+		assert.EqualValues(t, testCase.column, actual.Column, testCase.description)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := &codeError{code: "23505", message: `duplicate key value violates unique constraint "uk_email"`}
+	err := Wrap("insert", "users", cause)
+	assert.True(t, errors.Is(err, ErrDuplicateKey))
+	assert.True(t, errors.Is(err, ErrConstraint))
+	assert.True(t, errors.Is(err, cause))
+	assert.True(t, IsDuplicateKey(err))
+	var actual *Error
+	if assert.True(t, errors.As(err, &actual)) {
+		assert.EqualValues(t, "uk_email", actual.Constraint)
+		assert.EqualValues(t, "23505", actual.Code)
+	}
+	assert.Contains(t, err.Error(), "constraint=uk_email")
+
+	unknown := errors.New("syntax error")
+	assert.Equal(t, unknown, Wrap("insert", "users", unknown))
+}
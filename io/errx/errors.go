@@ -15,7 +15,8 @@ var (
 	// Note: many drivers return opaque error types; use IsDuplicateKey to detect.
 	ErrDuplicateKey = errors.New("duplicate key")
 
-	// ErrConstraint indicates a generic constraint violation (FK/CK/NOT NULL/etc).
+	// ErrConstraint indicates a generic constraint violation (FK/CK/NOT NULL/etc),
+	// errors.Is(err, ErrConstraint) matches all constraint violation kinds.
 	ErrConstraint = errors.New("constraint violation")
 )
 
@@ -26,6 +27,8 @@ type Error struct {
 	Table         string
 	Columns       []string
 	IdentityIndex int
+	Code          string
+	Constraint    string
 	Cause         error
 }
 
@@ -46,6 +49,10 @@ func (e *Error) Error() string {
 		sb.WriteString(" table=")
 		sb.WriteString(e.Table)
 	}
+	if e.Constraint != "" {
+		sb.WriteString(" constraint=")
+		sb.WriteString(e.Constraint)
+	}
 	if e.IdentityIndex != 0 {
 		sb.WriteString(fmt.Sprintf(" identityIndex=%d", e.IdentityIndex))
 	}
@@ -70,6 +77,9 @@ func (e *Error) Is(target error) bool {
 	if e.Kind != nil && target == e.Kind {
 		return true
 	}
+	if target == ErrConstraint && isConstraintKind(e.Kind) {
+		return true
+	}
 	if e.Cause != nil {
 		return errors.Is(e.Cause, target)
 	}
@@ -87,45 +97,55 @@ func MissingIdentity(op, table string, columns []string, identityIndex int) erro
 }
 
 func DuplicateKey(op, table string, cause error) error {
-	return &Error{
-		Kind:  ErrDuplicateKey,
-		Op:    op,
-		Table: table,
-		Cause: cause,
-	}
+	return classified(ErrDuplicateKey, op, table, cause)
 }
 
 func Constraint(op, table string, cause error) error {
-	return &Error{
-		Kind:  ErrConstraint,
+	return classified(ErrConstraint, op, table, cause)
+}
+
+// Wrap wraps classified driver error with structured context, unrecognized error is returned as is
+func Wrap(op, table string, err error) error {
+	classification := Classify(err)
+	if classification == nil {
+		return err
+	}
+	return classified(classification.Kind, op, table, err)
+}
+
+func classified(kind error, op, table string, cause error) error {
+	result := &Error{
+		Kind:  kind,
 		Op:    op,
 		Table: table,
 		Cause: cause,
 	}
+	if classification := Classify(cause); classification != nil {
+		if kind == ErrConstraint && isConstraintKind(classification.Kind) {
+			result.Kind = classification.Kind
+		}
+		result.Code = classification.Code
+		result.Constraint = classification.Constraint
+		if classification.Column != "" {
+			result.Columns = []string{classification.Column}
+		}
+	}
+	return result
 }
 
 func IsMissingIdentity(err error) bool { return errors.Is(err, ErrMissingIdentity) }
 
-func IsDuplicateKey(err error) bool {
-	if errors.Is(err, ErrDuplicateKey) {
-		return true
-	}
-	msg := strings.ToLower(errString(err))
-	return strings.Contains(msg, "unique constraint") ||
-		strings.Contains(msg, "duplicate key") ||
-		strings.Contains(msg, "duplicate entry")
-}
+func IsDuplicateKey(err error) bool { return isKind(err, ErrDuplicateKey) }
 
+// IsConstraint returns true for any constraint violation: duplicate key, foreign key, not null or check
 func IsConstraint(err error) bool {
+	if err == nil {
+		return false
+	}
 	if errors.Is(err, ErrConstraint) {
 		return true
 	}
-	msg := strings.ToLower(errString(err))
-	return strings.Contains(msg, "constraint failed") ||
-		strings.Contains(msg, "violates") ||
-		strings.Contains(msg, "not null constraint") ||
-		strings.Contains(msg, "foreign key constraint") ||
-		strings.Contains(msg, "check constraint")
+	return isConstraintKind(kindOf(err))
 }
 
 func errString(err error) string {
@@ -0,0 +1,214 @@
+package explain
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/metadata/info"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultTimeout represents default EXPLAIN statement timeout
+	DefaultTimeout = 10 * time.Second
+	// DefaultConcurrency represents default max number of concurrently running EXPLAIN statements
+	DefaultConcurrency = 2
+)
+
+type (
+	// Plan represents slow statement execution plan
+	Plan struct {
+		Source   string
+		Table    string
+		SQL      string
+		Args     []interface{}
+		Duration time.Duration
+		Explain  string                   //EXPLAIN statement
+		Rows     []map[string]interface{} //EXPLAIN result rows
+		Plan     interface{}              //parsed JSON plan if product returns JSON document, otherwise Rows
+		Err      error                    //EXPLAIN error
+	}
+
+	// Callback handles slow statement plan
+	Callback func(ctx context.Context, plan *Plan)
+
+	// Detector represents slow statement hook, it runs product specific EXPLAIN for exec and query statements exceeding threshold
+	// on a separate connection in the background and hands the plan to callback, slow statements detected while
+	// max number of EXPLAIN statements is already running are dropped, so that slowed down database is not overloaded
+	Detector struct {
+		db        *sql.DB
+		threshold time.Duration
+		callback  Callback
+		timeout   time.Duration
+		dialect   *info.Dialect
+		slots     chan struct{}
+		dropped   uint64
+		mux       sync.Mutex
+		pending   sync.WaitGroup
+	}
+)
+
+// Before returns unchanged context
+func (d *Detector) Before(ctx context.Context, event *hook.Event) context.Context {
+	return ctx
+}
+
+// After detects slow statement
+func (d *Detector) After(ctx context.Context, event *hook.Event) {
+	if event.Duration < d.threshold || event.SQL == "" {
+		return
+	}
+	if event.Op != hook.OpExec && event.Op != hook.OpQuery {
+		return
+	}
+	plan := &Plan{
+		Source:   event.Source,
+		Table:    event.Table,
+		SQL:      event.SQL,
+		Args:     append([]interface{}{}, event.Args...), //args buffer can be reused by caller
+		Duration: event.Duration,
+	}
+	select {
+	case d.slots <- struct{}{}:
+	default:
+		atomic.AddUint64(&d.dropped, 1)
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	d.pending.Add(1)
+	go func() {
+		defer d.pending.Done()
+		defer func() { <-d.slots }()
+		plan.Err = d.explain(ctx, plan)
+		d.callback(ctx, plan)
+	}()
+}
+
+// Wait waits for pending EXPLAIN statements
+func (d *Detector) Wait() {
+	d.pending.Wait()
+}
+
+// Dropped returns number of slow statements not explained since max number of EXPLAIN statements was already running
+func (d *Detector) Dropped() uint64 {
+	return atomic.LoadUint64(&d.dropped)
+}
+
+// WithConcurrency sets max number of concurrently running EXPLAIN statements, it has to be set before detector is used
+func (d *Detector) WithConcurrency(concurrency int) *Detector {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	d.slots = make(chan struct{}, concurrency)
+	return d
+}
+
+// WithTimeout sets EXPLAIN statement timeout
+func (d *Detector) WithTimeout(timeout time.Duration) *Detector {
+	d.timeout = timeout
+	return d
+}
+
+// WithDialect sets dialect, otherwise dialect is detected on first slow statement
+func (d *Detector) WithDialect(dialect *info.Dialect) *Detector {
+	d.dialect = dialect
+	return d
+}
+
+func (d *Detector) explain(ctx context.Context, plan *Plan) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	dialect, err := d.ensureDialect(ctx)
+	if err != nil {
+		return err
+	}
+	if dialect.Explain == "" {
+		return fmt.Errorf("EXPLAIN is not supported for %v", dialect.Name)
+	}
+	plan.Explain = dialect.Explain + plan.SQL
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	rows, err := conn.QueryContext(ctx, plan.Explain, plan.Args...)
+	if err != nil {
+		return fmt.Errorf("failed to explain: %v, %w", plan.SQL, err)
+	}
+	defer rows.Close()
+	if plan.Rows, err = readRows(rows); err != nil {
+		return err
+	}
+	plan.Plan = parse(plan.Rows)
+	return nil
+}
+
+func (d *Detector) ensureDialect(ctx context.Context) (*info.Dialect, error) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.dialect != nil {
+		return d.dialect, nil
+	}
+	dialect, err := config.Dialect(ctx, d.db)
+	if err != nil {
+		return nil, err
+	}
+	d.dialect = dialect
+	return dialect, nil
+}
+
+func readRows(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if data, ok := values[i].([]byte); ok {
+				values[i] = string(data)
+			}
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// parse returns JSON document for single value result (MySQL, PostgreSQL), otherwise rows
+func parse(rows []map[string]interface{}) interface{} {
+	if len(rows) == 1 && len(rows[0]) == 1 {
+		for _, value := range rows[0] {
+			if text, ok := value.(string); ok {
+				text = strings.TrimSpace(text)
+				if strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") {
+					var document interface{}
+					if err := json.Unmarshal([]byte(text), &document); err == nil {
+						return document
+					}
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// New creates slow statement detector
+func New(db *sql.DB, threshold time.Duration, callback Callback) *Detector {
+	return &Detector{db: db, threshold: threshold, callback: callback, timeout: DefaultTimeout, slots: make(chan struct{}, DefaultConcurrency)}
+}
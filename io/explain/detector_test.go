@@ -0,0 +1,111 @@
+package explain_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/explain"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/read"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDetector_After(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,primaryKey=true"`
+		Name string `sqlx:"name"`
+	}
+	var testCases = []struct {
+		description string
+		threshold   time.Duration
+		expectPlans int
+	}{
+		{
+			description: "statements exceeding threshold are explained",
+			threshold:   0,
+			expectPlans: 2,
+		},
+		{
+			description: "fast statements are ignored",
+			threshold:   time.Hour,
+		},
+	}
+
+	for _, testCase := range testCases {
+		db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "explain.db"))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, err = db.Exec("CREATE TABLE explain_entity (id INTEGER PRIMARY KEY, name TEXT)")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var plans []*explain.Plan
+		var mux sync.Mutex
+		detector := explain.New(db, testCase.threshold, func(ctx context.Context, plan *explain.Plan) {
+			mux.Lock()
+			defer mux.Unlock()
+			plans = append(plans, plan)
+		})
+
+		service, err := insert.New(context.TODO(), db, "explain_entity")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, _, err = service.Exec(context.TODO(), []*entity{{ID: 1, Name: "a"}}, detector)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		reader, err := read.New(context.TODO(), db, "SELECT id, name FROM explain_entity WHERE id = ?", func() interface{} { return &entity{} }, read.WithHooks(detector))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error { return nil }, 1)
+		assert.Nil(t, err, testCase.description)
+		detector.Wait()
+		_ = db.Close()
+
+		if !assert.Len(t, plans, testCase.expectPlans, testCase.description) {
+			continue
+		}
+		for _, plan := range plans {
+			assert.Nil(t, plan.Err, testCase.description)
+			assert.Contains(t, plan.Explain, "EXPLAIN QUERY PLAN ", testCase.description)
+			if plan.Source != "read" { //sqlite returns empty plan for INSERT ... VALUES
+				continue
+			}
+			if assert.NotEmpty(t, plan.Rows, testCase.description) {
+				assert.Contains(t, plan.Rows[0], "detail", testCase.description)
+			}
+		}
+	}
+}
+
+func TestDetector_Concurrency(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "explain.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	release := make(chan bool)
+	var explained int32
+	detector := explain.New(db, 0, func(ctx context.Context, plan *explain.Plan) {
+		atomic.AddInt32(&explained, 1)
+		<-release
+	}).WithConcurrency(1)
+	event := &hook.Event{Op: hook.OpQuery, Source: "read", SQL: "SELECT 1", Duration: time.Second}
+	for i := 0; i < 3; i++ { //first explain holds the only slot, the others are dropped
+		detector.After(context.Background(), event)
+	}
+	close(release)
+	detector.Wait()
+	assert.EqualValues(t, 1, atomic.LoadInt32(&explained))
+	assert.EqualValues(t, 2, detector.Dropped())
+}
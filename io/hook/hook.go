@@ -0,0 +1,107 @@
+package hook
+
+import (
+	"context"
+	"time"
+)
+
+// Operation represents hooked database operation
+type Operation string
+
+// Hooked operations
+const (
+	OpPrepare  = Operation("prepare")
+	OpExec     = Operation("exec")
+	OpQuery    = Operation("query")
+	OpBegin    = Operation("begin")
+	OpCommit   = Operation("commit")
+	OpRollback = Operation("rollback")
+)
+
+type (
+	// Event represents hooked operation event, RowsAffected, Duration and Err are set before After is called
+	Event struct {
+		Op           Operation
+		Source       string //originating service: read, insert, update, delete, load, merge, metadata
+		Table        string
+		SQL          string
+		Args         []interface{}
+		RowsAffected int64
+		Started      time.Time
+		Duration     time.Duration
+		Err          error
+	}
+
+	// Hook represents SQL operation hook, context returned by Before is used for the operation and passed to After
+	Hook interface {
+		Before(ctx context.Context, event *Event) context.Context
+		After(ctx context.Context, event *Event)
+	}
+
+	// Redactor returns args safe to be exposed to hooks
+	Redactor func(SQL string, args []interface{}) []interface{}
+
+	// Done completes hooked operation
+	Done func(rowsAffected int64, err error)
+
+	// Chain represents ordered hooks, Before hooks are called in order, After in reverse order
+	Chain struct {
+		hooks    []Hook
+		redactor Redactor
+	}
+)
+
+// RedactAll replaces all args with "?"
+var RedactAll Redactor = func(SQL string, args []interface{}) []interface{} {
+	result := make([]interface{}, len(args))
+	for i := range result {
+		result[i] = "?"
+	}
+	return result
+}
+
+// WithRedactor sets args redactor
+func (c *Chain) WithRedactor(redactor Redactor) *Chain {
+	c.redactor = redactor
+	return c
+}
+
+// Before calls hooks before operation
+func (c *Chain) Before(ctx context.Context, event *Event) context.Context {
+	if c.redactor != nil && len(event.Args) > 0 {
+		event.Args = c.redactor(event.SQL, event.Args)
+	}
+	for _, hook := range c.hooks {
+		ctx = hook.Before(ctx, event)
+	}
+	return ctx
+}
+
+// After calls hooks after operation
+func (c *Chain) After(ctx context.Context, event *Event) {
+	for i := len(c.hooks) - 1; i >= 0; i-- {
+		c.hooks[i].After(ctx, event)
+	}
+}
+
+// Start starts hooked operation, it returns operation context and function completing operation, nil chain is a no-op
+func (c *Chain) Start(ctx context.Context, event *Event) (context.Context, Done) {
+	if c == nil {
+		return ctx, noop
+	}
+	event.Started = time.Now()
+	ctx = c.Before(ctx, event)
+	return ctx, func(rowsAffected int64, err error) {
+		event.RowsAffected = rowsAffected
+		event.Duration = time.Since(event.Started)
+		event.Err = err
+		c.After(ctx, event)
+	}
+}
+
+func noop(int64, error) {}
+
+// New creates hooks chain
+func New(hooks ...Hook) *Chain {
+	return &Chain{hooks: hooks}
+}
@@ -0,0 +1,109 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type recorder struct {
+	name  string
+	calls *[]string
+}
+
+func (r *recorder) Before(ctx context.Context, event *Event) context.Context {
+	*r.calls = append(*r.calls, r.name+".before."+string(event.Op))
+	return ctx
+}
+
+func (r *recorder) After(ctx context.Context, event *Event) {
+	*r.calls = append(*r.calls, r.name+".after."+string(event.Op))
+}
+
+type span struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *span) SetAttributes(attributes map[string]interface{}) { s.attributes = attributes }
+func (s *span) RecordError(err error)                           { s.err = err }
+func (s *span) End()                                            { s.ended = true }
+
+type tracer struct {
+	spans []*span
+}
+
+func (t *tracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	result := &span{name: name}
+	t.spans = append(t.spans, result)
+	return ctx, result
+}
+
+func TestChain_Start(t *testing.T) {
+	var calls []string
+	chain := New(&recorder{name: "a", calls: &calls}, &recorder{name: "b", calls: &calls})
+	args := []interface{}{1, "secret"}
+	event := &Event{Op: OpExec, Source: "insert", Table: "foo", SQL: "INSERT INTO foo(id, pwd) VALUES(?, ?)", Args: args}
+	_, done := chain.WithRedactor(RedactAll).Start(context.Background(), event)
+	done(1, nil)
+	assert.EqualValues(t, []string{"a.before.exec", "b.before.exec", "b.after.exec", "a.after.exec"}, calls)
+	assert.EqualValues(t, []interface{}{"?", "?"}, event.Args)
+	assert.EqualValues(t, []interface{}{1, "secret"}, args)
+	assert.EqualValues(t, 1, event.RowsAffected)
+
+	var nilChain *Chain
+	ctx, done := nilChain.Start(context.Background(), event)
+	assert.NotNil(t, ctx)
+	done(0, nil)
+}
+
+func TestSlog_After(t *testing.T) {
+	var testCases = []struct {
+		description string
+		event       *Event
+		err         error
+		expect      []string
+	}{
+		{
+			description: "exec",
+			event:       &Event{Op: OpExec, Source: "update", Table: "foo", SQL: "UPDATE foo SET name = ? WHERE id = ?", Args: []interface{}{"x", 1}},
+			expect:      []string{"level=DEBUG", `msg="sqlx exec"`, "source=update", "table=foo", `sql="UPDATE foo SET name = ? WHERE id = ?"`, "args=\"[x 1]\"", "rows=1"},
+		},
+		{
+			description: "failed commit",
+			event:       &Event{Op: OpCommit},
+			err:         errors.New("tx done"),
+			expect:      []string{"level=ERROR", `msg="sqlx commit"`, `error="tx done"`},
+		},
+	}
+	for _, testCase := range testCases {
+		buffer := new(bytes.Buffer)
+		logger := slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		_, done := New(NewSlog(logger, slog.LevelDebug)).Start(context.Background(), testCase.event)
+		done(1, testCase.err)
+		for _, fragment := range testCase.expect {
+			assert.True(t, strings.Contains(buffer.String(), fragment), testCase.description+": "+fragment+" in "+buffer.String())
+		}
+	}
+}
+
+func TestTracing(t *testing.T) {
+	aTracer := &tracer{}
+	chain := New(NewTracing(aTracer))
+	_, done := chain.Start(context.Background(), &Event{Op: OpQuery, Source: "read", SQL: "SELECT 1"})
+	done(0, errors.New("failed"))
+	if !assert.Len(t, aTracer.spans, 1) {
+		return
+	}
+	actual := aTracer.spans[0]
+	assert.EqualValues(t, "sqlx.read.query", actual.name)
+	assert.EqualValues(t, "SELECT 1", actual.attributes["db.statement"])
+	assert.NotNil(t, actual.err)
+	assert.True(t, actual.ended)
+}
@@ -0,0 +1,36 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Printer represents hook printing SQL of selected operations
+type Printer struct {
+	writer io.Writer
+	ops    map[Operation]bool
+}
+
+// Before prints operation SQL
+func (p *Printer) Before(ctx context.Context, event *Event) context.Context {
+	if event.SQL != "" && p.ops[event.Op] {
+		fmt.Fprintln(p.writer, event.SQL)
+	}
+	return ctx
+}
+
+// After does nothing
+func (p *Printer) After(ctx context.Context, event *Event) {}
+
+// NewPrinter creates printer for supplied operations, prepare operation is printed by default
+func NewPrinter(writer io.Writer, ops ...Operation) *Printer {
+	if len(ops) == 0 {
+		ops = []Operation{OpPrepare}
+	}
+	result := &Printer{writer: writer, ops: map[Operation]bool{}}
+	for _, op := range ops {
+		result.ops[op] = true
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package hook
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Slog represents log/slog hook, it logs completed operations, failed operations are logged with error level
+type Slog struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// Before returns unchanged context
+func (s *Slog) Before(ctx context.Context, event *Event) context.Context {
+	return ctx
+}
+
+// After logs completed operation
+func (s *Slog) After(ctx context.Context, event *Event) {
+	level := s.level
+	if event.Err != nil {
+		level = slog.LevelError
+	}
+	if !s.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := make([]slog.Attr, 0, 8)
+	attrs = append(attrs, slog.String("op", string(event.Op)), slog.Duration("duration", event.Duration))
+	if event.Source != "" {
+		attrs = append(attrs, slog.String("source", event.Source))
+	}
+	if event.Table != "" {
+		attrs = append(attrs, slog.String("table", event.Table))
+	}
+	if event.SQL != "" {
+		attrs = append(attrs, slog.String("sql", event.SQL))
+	}
+	if len(event.Args) > 0 {
+		attrs = append(attrs, slog.Any("args", event.Args))
+	}
+	if event.RowsAffected > 0 {
+		attrs = append(attrs, slog.Int64("rows", event.RowsAffected))
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+	s.logger.LogAttrs(ctx, level, "sqlx "+string(event.Op), attrs...)
+}
+
+// NewSlog creates log/slog hook, nil logger uses slog.Default()
+func NewSlog(logger *slog.Logger, level slog.Level) *Slog {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Slog{logger: logger, level: level}
+}
@@ -0,0 +1,70 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// Span represents OpenTelemetry style span
+	Span interface {
+		SetAttributes(attributes map[string]interface{})
+		RecordError(err error)
+		End()
+	}
+
+	// Tracer represents OpenTelemetry style tracer, it starts span with the supplied name
+	Tracer interface {
+		Start(ctx context.Context, name string) (context.Context, Span)
+	}
+
+	// Tracing represents tracing hook, it starts span for every hooked operation
+	Tracing struct {
+		tracer Tracer
+	}
+
+	spanKey struct{}
+)
+
+// Before starts operation span
+func (t *Tracing) Before(ctx context.Context, event *Event) context.Context {
+	name := "sqlx." + string(event.Op)
+	if event.Source != "" {
+		name = "sqlx." + event.Source + "." + string(event.Op)
+	}
+	ctx, span := t.tracer.Start(ctx, name)
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// After ends operation span
+func (t *Tracing) After(ctx context.Context, event *Event) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return
+	}
+	attributes := map[string]interface{}{
+		"db.operation": string(event.Op),
+	}
+	if event.Table != "" {
+		attributes["db.sql.table"] = event.Table
+	}
+	if event.SQL != "" {
+		attributes["db.statement"] = event.SQL
+	}
+	if len(event.Args) > 0 {
+		attributes["db.statement.args"] = fmt.Sprintf("%v", event.Args)
+	}
+	if event.RowsAffected > 0 {
+		attributes["db.rows_affected"] = event.RowsAffected
+	}
+	span.SetAttributes(attributes)
+	if event.Err != nil {
+		span.RecordError(event.Err)
+	}
+	span.End()
+}
+
+// NewTracing creates tracing hook
+func NewTracing(tracer Tracer) *Tracing {
+	return &Tracing{tracer: tracer}
+}
@@ -1,6 +1,7 @@
 package batcher
 
 import (
+	"context"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -21,20 +22,33 @@ type (
 		count       int32
 	}
 
-	// State represents state of batch
+	// State represents state of batch, it is shared by all batch callers
 	State struct {
 		err  error
-		lock sync.Mutex
+		done chan struct{}
 	}
 )
 
-// Wait returns state's error
+// Wait waits for batch flush and returns flush error
 func (s *State) Wait() error {
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	<-s.done
 	return s.err
 }
 
+// WaitContext waits for batch flush or context cancellation
+func (s *State) WaitContext(ctx context.Context) error {
+	select {
+	case <-s.done:
+		return s.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newState() *State {
+	return &State{done: make(chan struct{})}
+}
+
 // HasExpired  checks if the batch exceeds its max duration time
 func (b *Batch) HasExpired() bool {
 	return time.Now().Sub(b.started) > b.maxDuration
@@ -48,7 +62,6 @@ func (b *Batch) TryAcquire() bool {
 
 func (b *Batch) init() {
 	b.started = time.Now()
-	b.state.lock.Lock()
 	b.count = 0
 	b.flushed = 0
 }
@@ -57,8 +70,8 @@ func (b *Batch) reset() {
 	b.pool = nil
 	b.started = time.Now()
 	b.collection.Reset()
-	b.state.lock.Unlock()
-	b.state = &State{}
+	close(b.state.done)
+	b.state = newState()
 }
 
 // NewBatch creates a new batch
@@ -68,6 +81,6 @@ func NewBatch(rType reflect.Type, maxElements int, maxDurationMs int) *Batch {
 	batch.maxDuration = time.Millisecond * time.Duration(maxDurationMs)
 	batch.started = time.Now()
 	batch.collection = NewCollection(rType)
-	batch.state = &State{}
+	batch.state = newState()
 	return batch
 }
@@ -0,0 +1,92 @@
+package batcher_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/insert/batcher"
+	"github.com/viant/sqlx/io/metrics"
+	"testing"
+)
+
+func TestService_Close(t *testing.T) {
+	var testCases = []struct {
+		description string
+		dropTable   bool
+		expectRows  int
+		hasError    bool
+	}{
+		{
+			description: "pending batch is drained",
+			expectRows:  3,
+		},
+		{
+			description: "flush error is returned",
+			dropTable:   true,
+			hasError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		srv, db := newQueueBatcher(t, &batcher.Config{MaxDurationMs: 60000})
+		if srv == nil {
+			return
+		}
+		if testCase.dropTable {
+			_, err := db.Exec("DROP TABLE queue_entity")
+			assert.Nil(t, err, testCase.description)
+		}
+		var states []*batcher.State
+		for i := 0; i < 3; i++ {
+			state, err := srv.Collect(&queueEntity{Name: "n"})
+			if !assert.Nil(t, err, testCase.description) {
+				return
+			}
+			states = append(states, state)
+		}
+		err := srv.Close(context.Background())
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+		} else {
+			assert.Nil(t, err, testCase.description)
+			var count int
+			assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM queue_entity").Scan(&count), testCase.description)
+			assert.EqualValues(t, testCase.expectRows, count, testCase.description)
+		}
+		for _, state := range states {
+			assert.EqualValues(t, testCase.hasError, state.Wait() != nil, testCase.description)
+		}
+		_, err = srv.Collect(&queueEntity{Name: "closed"})
+		assert.ErrorIs(t, err, batcher.ErrClosed, testCase.description)
+		assert.Nil(t, srv.Close(context.Background()), testCase.description)
+
+		stats := srv.Metrics()
+		assert.EqualValues(t, 1, stats.Batches, testCase.description)
+		assert.EqualValues(t, 3, stats.Records, testCase.description)
+		assert.EqualValues(t, 3, stats.MaxBatchSize, testCase.description)
+		assert.EqualValues(t, testCase.hasError, stats.Failed == 1, testCase.description)
+		_ = db.Close()
+	}
+}
+
+func TestService_Flush(t *testing.T) {
+	collector := metrics.NewMemory()
+	srv, db := newQueueBatcher(t, &batcher.Config{MaxDurationMs: 60000, Collector: collector})
+	if srv == nil {
+		return
+	}
+	defer db.Close()
+	for round := 1; round <= 2; round++ {
+		_, err := srv.Collect(&queueEntity{Name: "n"})
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Nil(t, srv.Flush(context.Background()))
+		var count int
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM queue_entity").Scan(&count))
+		assert.EqualValues(t, round, count)
+	}
+	assert.EqualValues(t, 2, srv.Metrics().Batches)
+	assert.EqualValues(t, 1, srv.Metrics().AvgBatchSize())
+	batchLabels := metrics.Labels{Source: "batcher", Op: "flush"}
+	assert.EqualValues(t, 2, collector.Counter(metrics.Batches, batchLabels))
+	assert.EqualValues(t, 2, collector.Counter(metrics.Rows, metrics.Labels{Source: "insert", Table: "queue_entity", Op: "exec"}))
+}
@@ -0,0 +1,82 @@
+package batcher
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Metrics represents batcher flush metrics
+	Metrics struct {
+		batches      int64
+		records      int64
+		failed       int64
+		maxBatchSize int64
+		flushTime    int64
+		maxFlushTime int64
+		waitTime     int64
+	}
+
+	// Stats represents batcher metrics snapshot
+	Stats struct {
+		Batches      int64
+		Records      int64
+		Failed       int64
+		MaxBatchSize int64
+		// FlushTime represents total time spent executing batches
+		FlushTime    time.Duration
+		MaxFlushTime time.Duration
+		// WaitTime represents total time batches were collecting records before flush
+		WaitTime time.Duration
+	}
+)
+
+// AvgBatchSize returns average number of records in flushed batch
+func (s *Stats) AvgBatchSize() float64 {
+	if s.Batches == 0 {
+		return 0
+	}
+	return float64(s.Records) / float64(s.Batches)
+}
+
+// AvgFlushTime returns average batch execution time
+func (s *Stats) AvgFlushTime() time.Duration {
+	if s.Batches == 0 {
+		return 0
+	}
+	return s.FlushTime / time.Duration(s.Batches)
+}
+
+func (m *Metrics) observe(size int, wait, elapsed time.Duration, err error) {
+	atomic.AddInt64(&m.batches, 1)
+	atomic.AddInt64(&m.records, int64(size))
+	if err != nil {
+		atomic.AddInt64(&m.failed, 1)
+	}
+	atomic.AddInt64(&m.flushTime, int64(elapsed))
+	atomic.AddInt64(&m.waitTime, int64(wait))
+	storeMax(&m.maxBatchSize, int64(size))
+	storeMax(&m.maxFlushTime, int64(elapsed))
+}
+
+// Stats returns metrics snapshot
+func (m *Metrics) Stats() *Stats {
+	return &Stats{
+		Batches:      atomic.LoadInt64(&m.batches),
+		Records:      atomic.LoadInt64(&m.records),
+		Failed:       atomic.LoadInt64(&m.failed),
+		MaxBatchSize: atomic.LoadInt64(&m.maxBatchSize),
+		FlushTime:    time.Duration(atomic.LoadInt64(&m.flushTime)),
+		MaxFlushTime: time.Duration(atomic.LoadInt64(&m.maxFlushTime)),
+		WaitTime:     time.Duration(atomic.LoadInt64(&m.waitTime)),
+	}
+}
+
+func storeMax(addr *int64, value int64) {
+	for {
+		current := atomic.LoadInt64(addr)
+		if value <= current || atomic.CompareAndSwapInt64(addr, current, value) {
+			return
+		}
+	}
+}
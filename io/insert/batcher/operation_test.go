@@ -0,0 +1,180 @@
+package batcher_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/delete"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/insert/batcher"
+	"github.com/viant/sqlx/io/load"
+	"github.com/viant/sqlx/io/update"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	_ "github.com/viant/sqlx/metadata/product/sqlite/load"
+	"path"
+	"reflect"
+	"testing"
+)
+
+type opEntity struct {
+	ID    int          `sqlx:"name=id,primaryKey=true"`
+	Name  string       `sqlx:"name"`
+	Score int          `sqlx:"score"`
+	Has   *opEntityHas `sqlx:"-" setMarker:"true"`
+}
+
+type opEntityHas struct {
+	ID    bool
+	Name  bool
+	Score bool
+}
+
+type opRow struct {
+	ID    int
+	Name  string
+	Score int
+}
+
+func newOpDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "op.db"))
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	for _, SQL := range []string{
+		"CREATE TABLE op_entity (id INTEGER PRIMARY KEY, name TEXT, score INTEGER)",
+		"INSERT INTO op_entity(id, name, score) VALUES (1, 'a', 10), (2, 'b', 20), (3, 'c', 30)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return nil
+		}
+	}
+	return db
+}
+
+func readOpRows(t *testing.T, db *sql.DB) []opRow {
+	rows, err := db.Query("SELECT id, name, score FROM op_entity ORDER BY id")
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	defer rows.Close()
+	var result []opRow
+	for rows.Next() {
+		row := opRow{}
+		if !assert.Nil(t, rows.Scan(&row.ID, &row.Name, &row.Score)) {
+			return nil
+		}
+		result = append(result, row)
+	}
+	return result
+}
+
+func TestService_Operations(t *testing.T) {
+	var testCases = []struct {
+		description string
+		newService  func(db *sql.DB) (*batcher.Service, error)
+		records     []*opEntity
+		expect      []opRow
+	}{
+		{
+			description: "update grouped by changed fields",
+			newService: func(db *sql.DB) (*batcher.Service, error) {
+				updater, err := update.New(context.Background(), db, "op_entity")
+				if err != nil {
+					return nil, err
+				}
+				return batcher.NewUpdater(context.Background(), updater, reflect.TypeOf(&opEntity{}), &batcher.Config{MaxDurationMs: 50})
+			},
+			records: []*opEntity{
+				{ID: 1, Name: "a1", Has: &opEntityHas{ID: true, Name: true}},
+				{ID: 2, Score: 21, Has: &opEntityHas{ID: true, Score: true}},
+				{ID: 3, Name: "c1", Has: &opEntityHas{ID: true, Name: true}},
+			},
+			expect: []opRow{{1, "a1", 10}, {2, "b", 21}, {3, "c1", 30}},
+		},
+		{
+			description: "upsert",
+			newService: func(db *sql.DB) (*batcher.Service, error) {
+				loader, err := load.New(context.Background(), db, "op_entity")
+				if err != nil {
+					return nil, err
+				}
+				return batcher.NewUpserter(context.Background(), loader, reflect.TypeOf(&opEntity{}), &batcher.Config{MaxDurationMs: 50})
+			},
+			records: []*opEntity{
+				{ID: 2, Name: "b2", Score: 22},
+				{ID: 4, Name: "d", Score: 40},
+			},
+			expect: []opRow{{1, "a", 10}, {2, "b2", 22}, {3, "c", 30}, {4, "d", 40}},
+		},
+		{
+			description: "delete by identity",
+			newService: func(db *sql.DB) (*batcher.Service, error) {
+				deleter, err := delete.New(context.Background(), db, "op_entity")
+				if err != nil {
+					return nil, err
+				}
+				return batcher.NewDeleter(context.Background(), deleter, reflect.TypeOf(&opEntity{}), &batcher.Config{MaxDurationMs: 50})
+			},
+			records: []*opEntity{{ID: 1}, {ID: 3}},
+			expect:  []opRow{{2, "b", 20}},
+		},
+	}
+	for _, testCase := range testCases {
+		db := newOpDB(t)
+		if db == nil {
+			return
+		}
+		srv, err := testCase.newService(db)
+		if !assert.Nil(t, err, testCase.description) {
+			_ = db.Close()
+			continue
+		}
+		var states []*batcher.State
+		for _, record := range testCase.records {
+			state, err := srv.Collect(record)
+			if !assert.Nil(t, err, testCase.description) {
+				return
+			}
+			states = append(states, state)
+		}
+		for _, state := range states {
+			assert.Nil(t, state.Wait(), testCase.description)
+		}
+		assert.EqualValues(t, testCase.expect, readOpRows(t, db), testCase.description)
+		_ = db.Close()
+	}
+}
+
+func TestService_Collect_Generator(t *testing.T) {
+	type genEntity struct {
+		ID   string `sqlx:"id,primaryKey,generator=uuidv7"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "gen.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE gen_entity (id TEXT PRIMARY KEY, name TEXT)"); !assert.Nil(t, err) {
+		return
+	}
+	inserter, err := insert.New(context.Background(), db, "gen_entity")
+	if !assert.Nil(t, err) {
+		return
+	}
+	srv, err := batcher.New(context.Background(), inserter, reflect.TypeOf(&genEntity{}), &batcher.Config{MaxDurationMs: 50})
+	if !assert.Nil(t, err) {
+		return
+	}
+	records := []*genEntity{{Name: "a"}, {Name: "b"}}
+	for _, record := range records {
+		_, err := srv.Collect(record)
+		assert.Nil(t, err)
+		assert.Len(t, record.ID, 36) //identity is assigned before flush
+	}
+	assert.Nil(t, srv.Close(context.Background()))
+	var count int
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM gen_entity WHERE id IN (?, ?)", records[0].ID, records[1].ID).Scan(&count))
+	assert.EqualValues(t, 2, count)
+}
@@ -0,0 +1,130 @@
+package batcher_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/insert/batcher"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type queueEntity struct {
+	ID   int    `sqlx:"name=id,primaryKey=true,generator=autoincrement"`
+	Name string `sqlx:"name"`
+}
+
+func newQueueBatcher(t *testing.T, config *batcher.Config) (*batcher.Service, *sql.DB) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "queue.db"))
+	if !assert.Nil(t, err) {
+		return nil, nil
+	}
+	if _, err = db.Exec("CREATE TABLE queue_entity (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)"); !assert.Nil(t, err) {
+		return nil, nil
+	}
+	inserter, err := insert.New(context.Background(), db, "queue_entity")
+	if !assert.Nil(t, err) {
+		return nil, nil
+	}
+	srv, err := batcher.New(context.Background(), inserter, reflect.TypeOf(&queueEntity{}), config)
+	if !assert.Nil(t, err) {
+		return nil, nil
+	}
+	return srv, db
+}
+
+func TestService_CollectContext_Queue(t *testing.T) {
+	var testCases = []struct {
+		description string
+		config      *batcher.Config
+		ctxTimeout  time.Duration
+		expectErr   error
+	}{
+		{
+			description: "reject",
+			config:      &batcher.Config{MaxDurationMs: 300, QueueCapacity: 2, QueuePolicy: batcher.QueuePolicyReject},
+			expectErr:   batcher.ErrQueueFull,
+		},
+		{
+			description: "timeout",
+			config:      &batcher.Config{MaxDurationMs: 300, QueueCapacity: 2, QueuePolicy: batcher.QueuePolicyTimeout, QueueTimeoutMs: 10},
+			expectErr:   batcher.ErrQueueTimeout,
+		},
+		{
+			description: "block canceled with context",
+			config:      &batcher.Config{MaxDurationMs: 300, QueueCapacity: 2},
+			ctxTimeout:  10 * time.Millisecond,
+			expectErr:   context.DeadlineExceeded,
+		},
+	}
+	for _, testCase := range testCases {
+		srv, db := newQueueBatcher(t, testCase.config)
+		if srv == nil {
+			return
+		}
+		var states []*batcher.State
+		for i := 0; i < 2; i++ {
+			state, err := srv.Collect(&queueEntity{Name: "n"})
+			if !assert.Nil(t, err, testCase.description) {
+				return
+			}
+			states = append(states, state)
+		}
+		ctx := context.Background()
+		if testCase.ctxTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, testCase.ctxTimeout)
+			defer cancel()
+		}
+		_, err := srv.CollectContext(ctx, &queueEntity{Name: "overflow"})
+		assert.ErrorIs(t, err, testCase.expectErr, testCase.description)
+
+		for _, state := range states {
+			assert.Nil(t, state.Wait(), testCase.description)
+		}
+		//capacity is released after flush
+		state, err := srv.CollectContext(context.Background(), &queueEntity{Name: "after flush"})
+		if assert.Nil(t, err, testCase.description) {
+			assert.Nil(t, state.Wait(), testCase.description)
+		}
+		var count int
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM queue_entity").Scan(&count), testCase.description)
+		assert.EqualValues(t, 3, count, testCase.description)
+		_ = db.Close()
+	}
+}
+
+func TestService_Collect_FlushError(t *testing.T) {
+	srv, db := newQueueBatcher(t, &batcher.Config{MaxDurationMs: 50, QueueCapacity: 10})
+	if srv == nil {
+		return
+	}
+	defer db.Close()
+	_, err := db.Exec("DROP TABLE queue_entity")
+	if !assert.Nil(t, err) {
+		return
+	}
+	var states []*batcher.State
+	for i := 0; i < 3; i++ {
+		state, err := srv.Collect(&queueEntity{Name: "n"})
+		if !assert.Nil(t, err) {
+			return
+		}
+		states = append(states, state)
+	}
+	for _, state := range states {
+		assert.NotNil(t, state.Wait())
+	}
+}
+
+func TestNew_QueuePolicy(t *testing.T) {
+	_, err := batcher.New(context.Background(), &insert.Service{}, reflect.TypeOf(&queueEntity{}), &batcher.Config{QueuePolicy: batcher.QueuePolicyTimeout})
+	assert.NotNil(t, err)
+	_, err = batcher.New(context.Background(), &insert.Service{}, reflect.TypeOf(&queueEntity{}), &batcher.Config{QueuePolicy: "drop"})
+	assert.NotNil(t, err)
+}
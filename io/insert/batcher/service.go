@@ -2,9 +2,18 @@ package batcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/viant/sqlx/io"
+	sdelete "github.com/viant/sqlx/io/delete"
 	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/insert/generator"
+	"github.com/viant/sqlx/io/load"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/io/update"
+	"github.com/viant/sqlx/loption"
 	"github.com/viant/sqlx/option"
+	"github.com/viant/xunsafe"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -17,23 +26,68 @@ const (
 	defaultBatchSize     = 100
 )
 
+var batchLabels = metrics.Labels{Source: "batcher", Op: "flush"}
+
+// QueuePolicy defines Collect behaviour when queue capacity is exhausted
+type QueuePolicy string
+
+const (
+	// QueuePolicyBlock blocks caller until queue has capacity or context is canceled
+	QueuePolicyBlock = QueuePolicy("block")
+	// QueuePolicyTimeout blocks caller up to QueueTimeoutMs
+	QueuePolicyTimeout = QueuePolicy("timeout")
+	// QueuePolicyReject returns ErrQueueFull right away
+	QueuePolicyReject = QueuePolicy("reject")
+)
+
+var (
+	// ErrQueueFull represents rejected Collect call due to exhausted queue capacity
+	ErrQueueFull = errors.New("batcher queue is full")
+	// ErrQueueTimeout represents Collect call that timed out waiting for queue capacity
+	ErrQueueTimeout = errors.New("batcher queue wait timeout")
+	// ErrClosed represents Collect call on closed batcher
+	ErrClosed = errors.New("batcher is closed")
+)
+
 // Service represents batcher service
 type Service struct {
-	inserter   *insert.Service
+	exec       executor
+	groupKey   func(recPtr interface{}) string
+	generator  *generator.Client
 	RecordType reflect.Type
 	config     *Config
-	batch      *Batch
+	batches    map[string]*Batch
+	flushing   map[*Batch]*State
 	batchPool  *pool
 	mux        sync.Mutex
 	ctx        context.Context
 	isWatching int32
+	closed     bool
+	queue      chan struct{}
+	metrics    Metrics
 }
 
+// executor flushes collected records (pointer to slice)
+type executor func(ctx context.Context, records interface{}) error
+
 // Config represents batcher's config
 type Config struct {
 	MaxElements   int
 	MaxDurationMs int
 	BatchSize     int
+	// QueueCapacity limits number of collected but not yet flushed records, 0 means unbounded
+	QueueCapacity  int
+	QueuePolicy    QueuePolicy
+	QueueTimeoutMs int
+	// Collector optionally collects batch metrics, it is also passed to underlying write service
+	Collector metrics.Collector
+}
+
+func (c *Config) options(options ...option.Option) []option.Option {
+	if c.Collector != nil {
+		options = append(options, c.Collector)
+	}
+	return options
 }
 
 // CanFlush checks possibility of flushing batch
@@ -43,31 +97,92 @@ func (s *Batch) CanFlush() bool {
 	return count == int(expectedCount)
 }
 
-func (s *Service) getActiveBatch() *Batch {
-	batch := s.batch
+func (s *Service) getActiveBatch(key string) *Batch {
+	batch := s.batches[key]
 	if batch != nil {
 		if batch.TryAcquire() {
 			return batch
 		}
-		go s.tryFlush(batch)
+		s.flushAsync(batch)
 	}
 	batch = s.batchPool.Get()
 	if atomic.CompareAndSwapInt32(&s.isWatching, 0, 1) {
 		go s.monitorBatchExpiry()
 	}
-	s.batch = batch
+	s.batches[key] = batch
 	return batch
 }
 
 // Collect puts data into a batch
 func (s *Service) Collect(recPtr interface{}) (*State, error) {
+	return s.CollectContext(s.ctx, recPtr)
+}
+
+// CollectContext puts data into a batch, when queue capacity is exhausted it applies queue policy,
+// blocking call can be canceled with supplied context
+func (s *Service) CollectContext(ctx context.Context, recPtr interface{}) (*State, error) {
+	if err := s.generator.Apply(recPtr); err != nil {
+		return nil, err
+	}
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	key := ""
+	if s.groupKey != nil {
+		key = s.groupKey(recPtr)
+	}
 	s.mux.Lock()
 	defer s.mux.Unlock()
-	batch := s.getActiveBatch()
+	if s.closed {
+		s.release(1)
+		return nil, ErrClosed
+	}
+	batch := s.getActiveBatch(key)
 	batch.collection.Append(recPtr)
 	return batch.state, nil
 }
 
+func (s *Service) acquire(ctx context.Context) error {
+	if s.queue == nil {
+		return nil
+	}
+	select {
+	case s.queue <- struct{}{}:
+		return nil
+	default:
+	}
+	switch s.config.QueuePolicy {
+	case QueuePolicyReject:
+		return ErrQueueFull
+	case QueuePolicyTimeout:
+		timer := time.NewTimer(time.Millisecond * time.Duration(s.config.QueueTimeoutMs))
+		defer timer.Stop()
+		select {
+		case s.queue <- struct{}{}:
+			return nil
+		case <-timer.C:
+			return ErrQueueTimeout
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	select {
+	case s.queue <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Service) release(count int) {
+	if s.queue == nil {
+		return
+	}
+	for i := 0; i < count; i++ {
+		<-s.queue
+	}
+}
+
 func (s *Service) monitorBatchExpiry() {
 	sleepTime := time.Millisecond * time.Duration(s.config.MaxDurationMs) / 2
 	for {
@@ -80,39 +195,177 @@ func (s *Service) monitorBatchExpiry() {
 
 func (s *Service) checkBatchExpiry() bool {
 	s.mux.Lock()
-	defer func() {
-		atomic.StoreInt32(&s.isWatching, 0)
-		s.mux.Unlock()
-	}()
-
-	batch := s.batch
-	if batch == nil {
-		return true
+	defer s.mux.Unlock()
+	for key, batch := range s.batches {
+		if batch.HasExpired() {
+			delete(s.batches, key)
+			s.flushAsync(batch)
+		}
 	}
-	if batch.HasExpired() {
-		s.batch = nil
-		go s.tryFlush(batch)
+	if len(s.batches) == 0 {
+		atomic.StoreInt32(&s.isWatching, 0)
 		return true
 	}
 	return false
 }
 
+// flushAsync flushes detached batch in the background, it has to be called with s.mux locked
+func (s *Service) flushAsync(aBatch *Batch) {
+	s.flushing[aBatch] = aBatch.state
+	go s.tryFlush(aBatch)
+}
+
 func (s *Service) tryFlush(aBatch *Batch) {
 	if atomic.CompareAndSwapInt32(&aBatch.flushed, 0, 1) {
-		_, _, err := s.inserter.Exec(s.ctx, aBatch.collection.newSlice, option.BatchSize(s.config.BatchSize))
+		count := aBatch.collection.Len()
+		started := time.Now()
+		err := s.exec(s.ctx, aBatch.collection.newSlice)
+		s.metrics.observe(count, started.Sub(aBatch.started), time.Since(started), err)
+		if collector := s.config.Collector; collector != nil {
+			collector.Count(metrics.Batches, batchLabels, 1)
+			collector.Observe(metrics.BatchSize, batchLabels, float64(count))
+		}
 		aBatch.state.err = err
+		s.mux.Lock()
+		delete(s.flushing, aBatch)
+		s.mux.Unlock()
 		s.batchPool.Put(aBatch)
+		s.release(count)
+	}
+}
+
+// Flush flushes all pending batches and waits for in-flight batches, it returns joined batch errors
+func (s *Service) Flush(ctx context.Context) error {
+	s.mux.Lock()
+	for key, batch := range s.batches {
+		delete(s.batches, key)
+		s.flushAsync(batch)
+	}
+	var states = make([]*State, 0, len(s.flushing))
+	for _, state := range s.flushing {
+		states = append(states, state)
 	}
+	s.mux.Unlock()
+	var errs []error
+	for _, state := range states {
+		if err := state.WaitContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops accepting records, then flushes all pending batches, Collect on closed service returns ErrClosed
+func (s *Service) Close(ctx context.Context) error {
+	s.mux.Lock()
+	s.closed = true
+	s.mux.Unlock()
+	return s.Flush(ctx)
+}
+
+// Metrics returns batcher metrics
+func (s *Service) Metrics() *Stats {
+	return s.metrics.Stats()
 }
 
 //New creates a batcher service
 func New(ctx context.Context, inserter *insert.Service, rType reflect.Type, config *Config) (*Service, error) {
-	if config == nil {
-		return nil, fmt.Errorf("batcher's config is nil")
-	}
 	if inserter == nil {
 		return nil, fmt.Errorf("batcher's inserter is nil")
 	}
+	service, err := newService(ctx, rType, config, func(ctx context.Context, records interface{}) error {
+		_, _, err := inserter.Exec(ctx, records, config.options(option.BatchSize(config.BatchSize))...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	service.generator, err = generator.NewClient(rType)
+	return service, err
+}
+
+// NewUpdater creates a batcher service for updates, records are grouped into batches by changed field set (SetMarker),
+// so that each batch is flushed with the same update statement
+func NewUpdater(ctx context.Context, updater *update.Service, rType reflect.Type, config *Config) (*Service, error) {
+	if updater == nil {
+		return nil, fmt.Errorf("batcher's updater is nil")
+	}
+	service, err := newService(ctx, rType, config, func(ctx context.Context, records interface{}) error {
+		_, err := updater.Exec(ctx, records, config.options()...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	service.groupKey, err = changedFieldsKey(rType)
+	return service, err
+}
+
+// NewUpserter creates a batcher service for upserts, batches are flushed with loader upsert statement (mysql, sqlite, oracle)
+func NewUpserter(ctx context.Context, loader *load.Service, rType reflect.Type, config *Config) (*Service, error) {
+	if loader == nil {
+		return nil, fmt.Errorf("batcher's loader is nil")
+	}
+	service, err := newService(ctx, rType, config, func(ctx context.Context, records interface{}) error {
+		_, err := loader.Exec(ctx, records, loption.WithUpsert(), loption.WithCommonOptions(config.options()))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	service.generator, err = generator.NewClient(rType)
+	return service, err
+}
+
+// NewDeleter creates a batcher service for deletes by identity, batches are flushed with bulk delete statements
+func NewDeleter(ctx context.Context, deleter *sdelete.Service, rType reflect.Type, config *Config) (*Service, error) {
+	if deleter == nil {
+		return nil, fmt.Errorf("batcher's deleter is nil")
+	}
+	return newService(ctx, rType, config, func(ctx context.Context, records interface{}) error {
+		_, err := deleter.Exec(ctx, records, config.options(option.BatchSize(config.BatchSize))...)
+		return err
+	})
+}
+
+// changedFieldsKey returns function computing changed field set key, or nil if record type does not use SetMarker
+func changedFieldsKey(rType reflect.Type) (func(recPtr interface{}) string, error) {
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	setMarker := &option.SetMarker{}
+	columns, err := io.StructColumns(rType, setMarker)
+	if err != nil {
+		return nil, err
+	}
+	marker := setMarker.Marker
+	if marker == nil {
+		return nil, nil
+	}
+	identityIndex := io.Columns(columns).PrimaryKeys()
+	if identityIndex == -1 {
+		identityIndex = len(columns)
+	}
+	return func(recPtr interface{}) string {
+		ptr := xunsafe.AsPointer(recPtr)
+		key := make([]byte, identityIndex)
+		for i := range key {
+			key[i] = '0'
+			if marker.IsSet(ptr, i) {
+				key[i] = '1'
+			}
+		}
+		return string(key)
+	}, nil
+}
+
+func newService(ctx context.Context, rType reflect.Type, config *Config, exec executor) (*Service, error) {
+	if config == nil {
+		return nil, fmt.Errorf("batcher's config is nil")
+	}
 	if rType == nil {
 		return nil, fmt.Errorf("batcher's rType is nil")
 	}
@@ -126,18 +379,34 @@ func New(ctx context.Context, inserter *insert.Service, rType reflect.Type, conf
 	if config.BatchSize == 0 {
 		config.BatchSize = defaultBatchSize
 	}
+	if config.QueuePolicy == "" {
+		config.QueuePolicy = QueuePolicyBlock
+	}
+	switch config.QueuePolicy {
+	case QueuePolicyBlock, QueuePolicyReject:
+	case QueuePolicyTimeout:
+		if config.QueueTimeoutMs <= 0 {
+			return nil, fmt.Errorf("batcher's QueueTimeoutMs is required for %v policy", config.QueuePolicy)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported batcher's queue policy: %v", config.QueuePolicy)
+	}
 
 	provider := func() interface{} {
 		return NewBatch(rType, config.MaxElements, config.MaxDurationMs)
 	}
 	service := &Service{
-		inserter:   inserter,
+		exec:       exec,
 		RecordType: rType,
-		batch:      nil,
+		batches:    map[string]*Batch{},
+		flushing:   map[*Batch]*State{},
 		config:     config,
 		batchPool:  newPool(provider),
 		ctx:        ctx,
 	}
+	if config.QueueCapacity > 0 {
+		service.queue = make(chan struct{}, config.QueueCapacity)
+	}
 
 	return service, nil
 }
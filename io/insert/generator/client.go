@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Func represents client side value generator
+type Func func() (interface{}, error)
+
+// Built-in client side generator names
+const (
+	UUIDName      = "uuid"
+	UUIDv4Name    = "uuidv4"
+	UUIDv7Name    = "uuidv7"
+	ULIDName      = "ulid"
+	SnowflakeName = "snowflake"
+)
+
+var registry = struct {
+	funcs map[string]Func
+	mux   sync.RWMutex
+}{funcs: map[string]Func{}}
+
+var clients sync.Map
+
+// Register registers client side generator with name, registered generator replaces one with the same name
+func Register(name string, fn Func) {
+	registry.mux.Lock()
+	registry.funcs[name] = fn
+	registry.mux.Unlock()
+	clients.Range(func(key, value interface{}) bool {
+		clients.Delete(key)
+		return true
+	})
+}
+
+// Lookup returns client side generator for name
+func Lookup(name string) (Func, bool) {
+	registry.mux.RLock()
+	defer registry.mux.RUnlock()
+	fn, ok := registry.funcs[name]
+	return fn, ok
+}
+
+type (
+	// Client represents client side generator, it populates empty fields tagged with registered generator name
+	Client struct {
+		fields []*clientField
+	}
+
+	clientField struct {
+		*xunsafe.Field
+		generator string
+		fn        Func
+	}
+)
+
+// NewClient creates client side generator for record type, it returns nil if no field uses registered generator
+func NewClient(rType reflect.Type) (*Client, error) {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	if cached, ok := clients.Load(rType); ok {
+		return cached.(*Client), nil
+	}
+	var fields []*clientField
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		tag := io.ParseTag(field.Tag)
+		if tag.Generator == "" {
+			continue
+		}
+		fn, ok := Lookup(tag.Generator)
+		if !ok {
+			continue
+		}
+		if !isAssignable(field.Type) {
+			return nil, fmt.Errorf("unsupported %v generator field %v type: %v", tag.Generator, field.Name, field.Type)
+		}
+		fields = append(fields, &clientField{Field: xunsafe.NewField(field), generator: tag.Generator, fn: fn})
+	}
+	var client *Client
+	if len(fields) > 0 {
+		client = &Client{fields: fields}
+	}
+	clients.Store(rType, client)
+	return client, nil
+}
+
+// Apply populates empty generator fields for supplied record/s
+func (c *Client) Apply(any interface{}) error {
+	if c == nil {
+		return nil
+	}
+	valueAt, size, err := io.Values(any)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < size; i++ {
+		ptr := xunsafe.AsPointer(valueAt(i))
+		for _, field := range c.fields {
+			if err = field.apply(ptr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyClient populates empty fields tagged with registered generator name for supplied record/s
+func ApplyClient(any interface{}) error {
+	valueAt, size, err := io.Values(any)
+	if err != nil || size == 0 {
+		return err
+	}
+	client, err := NewClient(reflect.TypeOf(valueAt(0)))
+	if err != nil {
+		return err
+	}
+	return client.Apply(any)
+}
+
+func (f *clientField) apply(ptr unsafe.Pointer) error {
+	fieldValue := reflect.NewAt(f.Type, f.Pointer(ptr)).Elem()
+	if !fieldValue.IsZero() {
+		return nil
+	}
+	value, err := f.fn()
+	if err != nil {
+		return fmt.Errorf("failed to generate %v value for %v: %w", f.generator, f.Name, err)
+	}
+	target := fieldValue
+	if target.Kind() == reflect.Ptr {
+		target = reflect.New(f.Type.Elem()).Elem()
+	}
+	if err = assignGenerated(target, value); err != nil {
+		return fmt.Errorf("failed to assign %v value to %v: %w", f.generator, f.Name, err)
+	}
+	if fieldValue.Kind() == reflect.Ptr {
+		fieldValue.Set(target.Addr())
+	}
+	return nil
+}
+
+func assignGenerated(target reflect.Value, value interface{}) error {
+	source := reflect.ValueOf(value)
+	if target.Kind() == reflect.String {
+		if stringer, ok := value.(fmt.Stringer); ok {
+			target.SetString(stringer.String())
+			return nil
+		}
+	}
+	if !source.Type().ConvertibleTo(target.Type()) || (target.Kind() == reflect.String && source.Kind() != reflect.String) {
+		return fmt.Errorf("%T is not convertible to %v", value, target.Type())
+	}
+	target.Set(source.Convert(target.Type()))
+	return nil
+}
+
+func isAssignable(fType reflect.Type) bool {
+	if fType.Kind() == reflect.Ptr {
+		fType = fType.Elem()
+	}
+	switch fType.Kind() {
+	case reflect.String, reflect.Int, reflect.Int64, reflect.Uint64:
+		return true
+	case reflect.Array:
+		return fType.Len() == 16 && fType.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+func init() {
+	newUUID := func() (interface{}, error) {
+		return uuid.NewRandom()
+	}
+	Register(UUIDName, newUUID)
+	Register(UUIDv4Name, newUUID)
+	Register(UUIDv7Name, func() (interface{}, error) {
+		return uuid.NewV7()
+	})
+	Register(ULIDName, func() (interface{}, error) {
+		return NewULID()
+	})
+	defaultSnowflake, _ := NewSnowflake(0, DefaultNodeBits)
+	Register(SnowflakeName, defaultSnowflake.Generate)
+}
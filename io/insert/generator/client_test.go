@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestApplyClient(t *testing.T) {
+	type uuidRecord struct {
+		ID   string `sqlx:"id,primaryKey,generator=uuidv7"`
+		Name string `sqlx:"name"`
+	}
+	type mixedRecord struct {
+		ID      int64     `sqlx:"id,primaryKey,generator=snowflake"`
+		Ref     *string   `sqlx:"ref,generator=ulid"`
+		Token   uuid.UUID `sqlx:"token,generator=uuid"`
+		Default string    `sqlx:"created,generator=default"`
+	}
+	type invalidRecord struct {
+		ID bool `sqlx:"id,generator=uuid"`
+	}
+	existing := "existing"
+
+	var testCases = []struct {
+		description string
+		records     interface{}
+		hasError    bool
+		assertFn    func(t *testing.T)
+	}{
+		{
+			description: "uuid v7 for empty fields only",
+			records:     []*uuidRecord{{}, {ID: "abc"}},
+		},
+		{
+			description: "mixed generators",
+			records:     []*mixedRecord{{}, {Ref: &existing}},
+		},
+		{
+			description: "unsupported field type",
+			records:     &invalidRecord{},
+			hasError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		err := ApplyClient(testCase.records)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		switch actual := testCase.records.(type) {
+		case []*uuidRecord:
+			parsed, err := uuid.Parse(actual[0].ID)
+			assert.Nil(t, err, testCase.description)
+			assert.EqualValues(t, 7, parsed.Version(), testCase.description)
+			assert.EqualValues(t, "abc", actual[1].ID, testCase.description)
+		case []*mixedRecord:
+			for _, record := range actual {
+				assert.True(t, record.ID > 0, testCase.description)
+				assert.NotEqual(t, uuid.Nil, record.Token, testCase.description)
+				assert.EqualValues(t, "", record.Default, testCase.description)
+			}
+			assert.True(t, actual[0].ID < actual[1].ID, testCase.description)
+			if assert.NotNil(t, actual[0].Ref, testCase.description) {
+				assert.Len(t, *actual[0].Ref, 26, testCase.description)
+			}
+			assert.EqualValues(t, "existing", *actual[1].Ref, testCase.description)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	type record struct {
+		ID int `sqlx:"id,generator=counter"`
+	}
+	counter := 0
+	Register("counter", func() (interface{}, error) {
+		counter++
+		return int64(counter), nil
+	})
+	records := []*record{{}, {}}
+	assert.Nil(t, ApplyClient(records))
+	assert.EqualValues(t, 1, records[0].ID)
+	assert.EqualValues(t, 2, records[1].ID)
+}
+
+func TestNewULID(t *testing.T) {
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		id, err := NewULID()
+		if !assert.Nil(t, err) {
+			return
+		}
+		ids = append(ids, id.String())
+	}
+	assert.True(t, sort.StringsAreSorted(ids))
+	assert.EqualValues(t, "00000000000000000000000000", ULID{}.String())
+	assert.EqualValues(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", ULID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}.String())
+}
+
+func TestSnowflake(t *testing.T) {
+	var testCases = []struct {
+		description string
+		node        int64
+		nodeBits    uint
+		hasError    bool
+	}{
+		{description: "default node bits", node: 5, nodeBits: DefaultNodeBits},
+		{description: "wide node", node: 1<<16 - 1, nodeBits: 16},
+		{description: "node out of range", node: 1 << 4, nodeBits: 4, hasError: true},
+		{description: "invalid node bits", nodeBits: 22, hasError: true},
+	}
+	for _, testCase := range testCases {
+		generator, err := NewSnowflake(testCase.node, testCase.nodeBits)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		sequenceBits := snowflakeSharedBits - testCase.nodeBits
+		prev := int64(0)
+		for i := 0; i < 10000; i++ {
+			id := generator.Next()
+			assert.True(t, id > prev, testCase.description)
+			assert.EqualValues(t, testCase.node, (id>>sequenceBits)&(1<<testCase.nodeBits-1), testCase.description)
+			prev = id
+		}
+	}
+}
@@ -183,7 +183,10 @@ func (d *Default) shouldLoadColumnInfo(rType reflect.Type) bool {
 
 	for i := 0; i < rType.NumField(); i++ {
 		tag := io.ParseTag(rType.Field(i).Tag)
-		if tag.Generator != "" && !(tag.PrimaryKey && tag.Autoincrement) {
+		if tag.Generator == "" || (tag.PrimaryKey && tag.Autoincrement) {
+			continue
+		}
+		if _, ok := Lookup(tag.Generator); !ok { //client side generators do not need column defaults
 			return true
 		}
 	}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultNodeBits represents default number of snowflake node bits
+	DefaultNodeBits = 10
+	// snowflakeSharedBits represents number of bits shared by node and sequence
+	snowflakeSharedBits = 22
+)
+
+// SnowflakeEpoch represents snowflake IDs epoch (2020-01-01 UTC)
+var SnowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Snowflake represents snowflake style 63 bits IDs generator: 41 bits of milliseconds since epoch,
+// followed by node bits and sequence bits (22 bits in total)
+type Snowflake struct {
+	node         int64
+	sequenceBits uint
+	sequenceMask int64
+	epochMs      int64
+	lastMs       int64
+	sequence     int64
+	mux          sync.Mutex
+}
+
+// Generate returns next ID as int64
+func (s *Snowflake) Generate() (interface{}, error) {
+	return s.Next(), nil
+}
+
+// Next returns next ID
+func (s *Snowflake) Next() int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	ms := time.Now().UnixMilli()
+	if ms < s.lastMs { //clock moved backwards, stay on last millisecond
+		ms = s.lastMs
+	}
+	if ms == s.lastMs {
+		s.sequence = (s.sequence + 1) & s.sequenceMask
+		if s.sequence == 0 {
+			for ms <= s.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				ms = time.Now().UnixMilli()
+			}
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMs = ms
+	return (ms-s.epochMs)<<snowflakeSharedBits | s.node<<s.sequenceBits | s.sequence
+}
+
+// NewSnowflake creates snowflake generator for node, nodeBits has to be between 1 and 21
+func NewSnowflake(node int64, nodeBits uint) (*Snowflake, error) {
+	if nodeBits < 1 || nodeBits >= snowflakeSharedBits {
+		return nil, fmt.Errorf("invalid snowflake node bits: %v, expected 1-%v", nodeBits, snowflakeSharedBits-1)
+	}
+	if node < 0 || node >= 1<<nodeBits {
+		return nil, fmt.Errorf("invalid snowflake node: %v, expected 0-%v", node, 1<<nodeBits-1)
+	}
+	sequenceBits := snowflakeSharedBits - nodeBits
+	return &Snowflake{
+		node:         node,
+		sequenceBits: sequenceBits,
+		sequenceMask: 1<<sequenceBits - 1,
+		epochMs:      SnowflakeEpoch.UnixMilli(),
+		lastMs:       -1,
+	}, nil
+}
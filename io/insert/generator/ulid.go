@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID represents universally unique lexicographically sortable identifier
+type ULID [16]byte
+
+var ulidState = struct {
+	lastMs  uint64
+	entropy [10]byte
+	mux     sync.Mutex
+}{}
+
+// NewULID returns new ULID, ULIDs generated within the same millisecond are monotonic
+func NewULID() (ULID, error) {
+	var result ULID
+	ms := uint64(time.Now().UnixMilli())
+	ulidState.mux.Lock()
+	defer ulidState.mux.Unlock()
+	if ms <= ulidState.lastMs {
+		ms = ulidState.lastMs
+		if !incrementEntropy(&ulidState.entropy) {
+			ms++ //entropy overflow, move to next millisecond
+			if _, err := rand.Read(ulidState.entropy[:]); err != nil {
+				return result, err
+			}
+		}
+	} else if _, err := rand.Read(ulidState.entropy[:]); err != nil {
+		return result, err
+	}
+	ulidState.lastMs = ms
+	var timestamp [8]byte
+	binary.BigEndian.PutUint64(timestamp[:], ms)
+	copy(result[:6], timestamp[2:])
+	copy(result[6:], ulidState.entropy[:])
+	return result, nil
+}
+
+// String returns 26 characters Crockford's base32 ULID representation
+func (u ULID) String() string {
+	var result [26]byte
+	//128 bits encoded from the least significant 5 bits group, first character holds top 3 bits
+	var hi = binary.BigEndian.Uint64(u[:8])
+	var lo = binary.BigEndian.Uint64(u[8:])
+	for i := 25; i >= 0; i-- {
+		result[i] = crockfordAlphabet[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(result[:])
+}
+
+func incrementEntropy(entropy *[10]byte) bool {
+	for i := len(entropy) - 1; i >= 0; i-- {
+		entropy[i]++
+		if entropy[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
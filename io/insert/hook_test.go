@@ -0,0 +1,64 @@
+package insert_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/option"
+	"path"
+	"testing"
+)
+
+type eventRecorder struct {
+	events []*hook.Event
+}
+
+func (r *eventRecorder) Before(ctx context.Context, event *hook.Event) context.Context {
+	return ctx
+}
+
+func (r *eventRecorder) After(ctx context.Context, event *hook.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestService_Exec_Hooks(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,primaryKey=true"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "hook.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE hook_entity (id INTEGER PRIMARY KEY, name TEXT)")
+	if !assert.Nil(t, err) {
+		return
+	}
+	service, err := insert.New(context.TODO(), db, "hook_entity")
+	if !assert.Nil(t, err) {
+		return
+	}
+	recorder := &eventRecorder{}
+	records := []*entity{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	_, _, err = service.Exec(context.TODO(), records, option.BatchSize(2), recorder, hook.RedactAll)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var ops []hook.Operation
+	for _, event := range recorder.events {
+		ops = append(ops, event.Op)
+		if event.Op == hook.OpExec {
+			assert.EqualValues(t, "insert", event.Source)
+			assert.EqualValues(t, "hook_entity", event.Table)
+			assert.Contains(t, event.SQL, "INSERT INTO hook_entity")
+			assert.Contains(t, event.Args, "?")
+			assert.NotContains(t, event.Args, "a")
+		}
+	}
+	assert.EqualValues(t, []hook.Operation{hook.OpBegin, hook.OpPrepare, hook.OpExec, hook.OpPrepare, hook.OpExec, hook.OpCommit}, ops)
+	assert.EqualValues(t, 2, recorder.events[2].RowsAffected)
+	assert.EqualValues(t, 1, recorder.events[4].RowsAffected)
+}
@@ -0,0 +1,62 @@
+package insert
+
+import (
+	"github.com/viant/sqlx/io"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"unsafe"
+)
+
+// identitySnapshot represents records identity fields values captured before insert
+type identitySnapshot struct {
+	fields  []*xunsafe.Field
+	records []unsafe.Pointer
+	values  [][]reflect.Value
+}
+
+// snapshotIdentities captures records identity fields values, so that retry can reset identities assigned by failed attempt
+func snapshotIdentities(any interface{}) (*identitySnapshot, error) {
+	valueAt, recordCount, err := io.Values(any)
+	if err != nil || recordCount == 0 {
+		return nil, err
+	}
+	columns, _, err := io.StructColumnMapper(valueAt(0))
+	if err != nil {
+		return nil, err
+	}
+	result := &identitySnapshot{}
+	for _, column := range columns {
+		fielder, ok := column.(io.Fielder)
+		if !ok || !io.IsIdentityColumn(column) {
+			continue
+		}
+		fields := fielder.Fields()
+		result.fields = append(result.fields, fields[len(fields)-1])
+	}
+	if len(result.fields) == 0 {
+		return nil, nil
+	}
+	for i := 0; i < recordCount; i++ {
+		recordPtr := xunsafe.AsPointer(valueAt(i))
+		values := make([]reflect.Value, len(result.fields))
+		for j, field := range result.fields {
+			values[j] = reflect.New(field.Type).Elem()
+			values[j].Set(reflect.NewAt(field.Type, field.Pointer(recordPtr)).Elem())
+		}
+		result.records = append(result.records, recordPtr)
+		result.values = append(result.values, values)
+	}
+	return result, nil
+}
+
+// restore sets records identity fields back to captured values
+func (s *identitySnapshot) restore() {
+	if s == nil {
+		return
+	}
+	for i, recordPtr := range s.records {
+		for j, field := range s.fields {
+			reflect.NewAt(field.Type, field.Pointer(recordPtr)).Elem().Set(s.values[i][j])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package insert_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/errx"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/retry"
+	"github.com/viant/sqlx/option"
+	"path"
+	"testing"
+)
+
+func TestService_Exec_Retry(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,primaryKey=true"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "retry.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE retry_entity (id INTEGER PRIMARY KEY, name TEXT)")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var testCases = []struct {
+		description   string
+		global        bool
+		expectRetries int
+	}{
+		{description: "service owned transaction retried", expectRetries: 2},
+		{description: "caller supplied transaction not retried", global: true},
+	}
+	for _, testCase := range testCases {
+		retries := 0
+		policy := retry.New(3, 0)
+		policy.Retryable = func(err error) bool {
+			retries++
+			return errx.IsDuplicateKey(err)
+		}
+		service, err := insert.New(context.TODO(), db, "retry_entity")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		options := []option.Option{policy, option.BatchSize(1)}
+		var tx *sql.Tx
+		if testCase.global {
+			tx, err = db.Begin()
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			options = append(options, tx)
+		}
+		_, _, err = service.Exec(context.TODO(), []*entity{{ID: 1, Name: "a"}, {ID: 1, Name: "b"}}, options...)
+		assert.True(t, errx.IsDuplicateKey(err), testCase.description)
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+		assert.EqualValues(t, testCase.expectRetries, retries, testCase.description)
+		var count int
+		assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM retry_entity").Scan(&count), testCase.description)
+		assert.EqualValues(t, 0, count, testCase.description)
+	}
+}
+
+func TestService_Exec_RetryIdentity(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,autoincrement,primaryKey=true"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "retry_identity.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE retry_entity (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT UNIQUE)")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = db.Exec("INSERT INTO retry_entity(name) VALUES('b')")
+	if !assert.Nil(t, err) {
+		return
+	}
+	policy := retry.New(2, 0)
+	policy.Retryable = func(err error) bool { //remove conflicting row and take identity assigned by rolled back attempt
+		_, _ = db.Exec("DELETE FROM retry_entity WHERE name = 'b'")
+		_, _ = db.Exec("INSERT INTO retry_entity(name) VALUES('c')")
+		return errx.IsDuplicateKey(err)
+	}
+	service, err := insert.New(context.TODO(), db, "retry_entity")
+	if !assert.Nil(t, err) {
+		return
+	}
+	records := []*entity{{Name: "a"}, {Name: "b"}}
+	affected, _, err := service.Exec(context.TODO(), records, policy, option.BatchSize(1))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, 2, affected)
+	for _, record := range records {
+		var name string
+		assert.Nil(t, db.QueryRow("SELECT name FROM retry_entity WHERE id = ?", record.ID).Scan(&name), record.Name)
+		assert.EqualValues(t, record.Name, name)
+	}
+}
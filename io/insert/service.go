@@ -10,6 +10,10 @@ import (
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/io/insert/generator"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/io/outbox"
+	"github.com/viant/sqlx/io/retry"
+	"github.com/viant/sqlx/io/router"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 )
@@ -23,6 +27,7 @@ type Service struct {
 	db                  *sql.DB
 	metaSessionCacheKey string
 	metaSessionCache    *sync.Map
+	shards              sync.Map
 }
 
 // New creates an inserter service
@@ -82,8 +87,36 @@ func (s *Service) NextSequence(ctx context.Context, any interface{}, recordCount
 	return nil, fmt.Errorf("not found column with sequence")
 }
 
-// Exec runs insertService SQL
+// Exec runs insertService SQL, retry policy option re-runs the whole service owned transaction on transient failure
+// option.ShardResolver splits records by shard and executes shard groups in parallel,
+// outbox.Outbox option appends events of written records within the same transaction
 func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.Option) (int64, int64, error) {
+	ctx = router.WithPrimary(ctx)
+	if resolver := s.shardResolver(options); resolver != nil {
+		return s.execShards(ctx, resolver, any, options...)
+	}
+	policy := retry.Owned(options)
+	if policy == nil {
+		return s.exec(ctx, any, options...)
+	}
+	identities, err := snapshotIdentities(any)
+	if err != nil {
+		return 0, 0, err
+	}
+	var rowsAffected, lastInsertedID int64
+	labels := metrics.Labels{Source: "insert", Table: s.tableName, Op: "exec"}
+	attempt := 0
+	err = policy.RunObserved(ctx, option.Options(options).Collector(), labels, func(ctx context.Context) (err error) {
+		if attempt++; attempt > 1 { //rolled back attempt may have assigned identities
+			identities.restore()
+		}
+		rowsAffected, lastInsertedID, err = s.exec(ctx, any, options...)
+		return err
+	})
+	return rowsAffected, lastInsertedID, err
+}
+
+func (s *Service) exec(ctx context.Context, any interface{}, options ...option.Option) (int64, int64, error) {
 	if options == nil {
 		options = make(option.Options, 0)
 	}
@@ -111,6 +144,10 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 		return 0, 0, err
 	}
 
+	if err = generator.ApplyClient(any); err != nil {
+		return 0, 0, err
+	}
+
 	for _, updater := range sess.recordUpdaters {
 		updaterOpts, err := updater.prepare(ctx, options, sess, valueAt, recordCount)
 		if err != nil {
@@ -143,6 +180,11 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 	}
 
 	rowsAffected, lastInsertedID, err := sess.insert(ctx, batchRecordBuffer, valueAt, recordCount, identities)
+	if err == nil {
+		var events *outbox.Outbox
+		option.Assign(options, &events)
+		err = events.Append(ctx, sess.Transaction, sess.db, sess.Dialect, sess.TableName, outbox.OpInsert, any)
+	}
 	err = sess.end(err)
 	return rowsAffected, lastInsertedID, err
 }
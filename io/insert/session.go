@@ -7,9 +7,12 @@ import (
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/io/errx"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/tenant"
 	"github.com/viant/sqlx/metadata/info/dialect"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
+	"github.com/viant/xunsafe"
 	"reflect"
 	"strings"
 )
@@ -24,6 +27,8 @@ type session struct {
 	columns        io.Columns
 	db             *sql.DB
 	stmt           *sql.Stmt
+	SQL            string
+	hooks          *hook.Chain
 	recordUpdaters []recordUpdater
 }
 
@@ -48,6 +53,7 @@ func (s *session) init(record interface{}) (err error) {
 
 func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
 	var err error
+	s.hooks = sqlHooks(options)
 	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
 	if err != nil {
 		return err
@@ -94,17 +100,21 @@ func (s *session) prepare(ctx context.Context, record interface{}, batchSize int
 		}
 		s.stmt = nil
 	}
-	if showSQL {
-		fmt.Println(SQL)
-	}
+	s.SQL = SQL
+	ctx, done := s.hooks.Start(ctx, s.event(hook.OpPrepare, nil))
 	if s.Transaction != nil {
 		s.stmt, err = s.Transaction.Prepare(SQL)
-		return err
+	} else {
+		s.stmt, err = s.db.PrepareContext(ctx, SQL)
 	}
-	s.stmt, err = s.db.PrepareContext(ctx, SQL)
+	done(0, err)
 	return err
 }
 
+func (s *session) event(op hook.Operation, args []interface{}) *hook.Event {
+	return &hook.Event{Op: op, Source: "insert", Table: s.TableName, SQL: s.SQL, Args: args}
+}
+
 func isClosedError(err error) bool {
 	return strings.Contains(err.Error(), "closed")
 }
@@ -114,6 +124,13 @@ func (s *session) insert(ctx context.Context, recValues []interface{}, valueAt i
 	var err error
 	var rowsAffected, totalRowsAffected, lastInsertedID int64
 	var record interface{}
+	tenantIndex := s.columns.Tenant()
+	var tenantID interface{}
+	if tenantIndex != -1 {
+		if tenantID, err = tenant.RequiredID(ctx); err != nil {
+			return 0, 0, err
+		}
+	}
 
 	for i := 0; i < size; i++ {
 		record = valueAt(i)
@@ -124,6 +141,11 @@ func (s *session) insert(ctx context.Context, recValues []interface{}, valueAt i
 			}
 		}
 
+		if tenantIndex != -1 {
+			if err = assignTenant(s.columns[tenantIndex], record, tenantID); err != nil {
+				return 0, 0, err
+			}
+		}
 		s.binder(record, recValues[offset:], 0, len(s.columns))
 		for _, updater := range s.recordUpdaters {
 			idIndex := offset + updater.columnPosition()
@@ -162,18 +184,15 @@ func (s *session) flush(ctx context.Context, values []interface{}, identities []
 	if s.Dialect.CanReturning {
 		return s.flushQuery(ctx, values, identities)
 	}
-	result, err := s.stmt.ExecContext(ctx, values...)
+	execCtx, done := s.hooks.Start(ctx, s.event(hook.OpExec, values))
+	result, err := s.stmt.ExecContext(execCtx, values...)
 	if err != nil {
-		if errx.IsDuplicateKey(err) {
-			return 0, 0, errx.DuplicateKey("insert", s.TableName, err)
-		}
-		if errx.IsConstraint(err) {
-			return 0, 0, errx.Constraint("insert", s.TableName, err)
-		}
-		return 0, 0, err
+		done(0, err)
+		return 0, 0, errx.Wrap("insert", s.TableName, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
+	done(rowsAffected, err)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -201,16 +220,17 @@ func (s *session) flush(ctx context.Context, values []interface{}, identities []
 }
 
 func (s *session) flushQuery(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
+	ctx, done := s.hooks.Start(ctx, s.event(hook.OpQuery, values))
+	rowsAffected, lastInsertedID, err := s.queryIdentities(ctx, values, identities)
+	done(rowsAffected, err)
+	return rowsAffected, lastInsertedID, err
+}
+
+func (s *session) queryIdentities(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
 	var rowsAffected, newLastInsertedID int64
 	rows, err := s.stmt.QueryContext(ctx, values...)
 	if err != nil {
-		if errx.IsDuplicateKey(err) {
-			return 0, 0, errx.DuplicateKey("insert", s.TableName, err)
-		}
-		if errx.IsConstraint(err) {
-			return 0, 0, errx.Constraint("insert", s.TableName, err)
-		}
-		return 0, 0, err
+		return 0, 0, errx.Wrap("insert", s.TableName, err)
 	}
 	defer io.RunWithError(rows.Close, &err)
 	rows.NextResultSet()
@@ -231,3 +251,29 @@ func (s *session) flushQuery(ctx context.Context, values []interface{}, identiti
 	}
 	return rowsAffected, newLastInsertedID, err
 }
+
+// assignTenant writes context tenant ID to record tenant field, so that caller sees persisted value
+func assignTenant(column io.Column, record interface{}, tenantID interface{}) error {
+	fielder, ok := column.(io.Fielder)
+	if !ok {
+		return fmt.Errorf("failed to assign tenant ID: column %v has no field", column.Name())
+	}
+	fields := fielder.Fields()
+	field := fields[len(fields)-1]
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	value := reflect.ValueOf(tenantID)
+	if !value.Type().ConvertibleTo(fieldType) {
+		return fmt.Errorf("failed to assign tenant ID %T to %v field type %v", tenantID, field.Name, field.Type)
+	}
+	value = value.Convert(fieldType)
+	if field.Type.Kind() == reflect.Ptr {
+		ptr := reflect.New(fieldType)
+		ptr.Elem().Set(value)
+		value = ptr
+	}
+	reflect.NewAt(field.Type, field.Pointer(xunsafe.AsPointer(record))).Elem().Set(value)
+	return nil
+}
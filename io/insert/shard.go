@@ -0,0 +1,57 @@
+package insert
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/option"
+	"sync"
+)
+
+// execShards splits records by shard and inserts shard groups in parallel, last inserted ID is the max across shards
+func (s *Service) execShards(ctx context.Context, resolver option.ShardResolver, any interface{}, options ...option.Option) (int64, int64, error) {
+	if option.Options(options).Tx() != nil {
+		return 0, 0, fmt.Errorf("shard resolver can not be used with global transaction")
+	}
+	groups, err := io.GroupByShard(any, resolver)
+	if err != nil {
+		return 0, 0, err
+	}
+	shardOptions := option.Options(options).Unsharded()
+	var rowsAffected, lastInsertedID int64
+	var mux sync.Mutex
+	err = io.RunShards(groups, func(group *io.ShardGroup) error {
+		shardService, err := s.shardService(ctx, group.Shard)
+		if err != nil {
+			return err
+		}
+		affected, lastID, err := shardService.Exec(ctx, group.Records, shardOptions...)
+		mux.Lock()
+		defer mux.Unlock()
+		rowsAffected += affected
+		if lastID > lastInsertedID {
+			lastInsertedID = lastID
+		}
+		return err
+	})
+	return rowsAffected, lastInsertedID, err
+}
+
+func (s *Service) shardResolver(options []option.Option) option.ShardResolver {
+	if resolver := option.Options(options).ShardResolver(); resolver != nil {
+		return resolver
+	}
+	return option.Options(s.options).ShardResolver()
+}
+
+func (s *Service) shardService(ctx context.Context, shard option.Shard) (*Service, error) {
+	if cached, ok := s.shards.Load(shard); ok {
+		return cached.(*Service), nil
+	}
+	shardService, err := New(ctx, shard.DB, s.tableName+shard.Suffix, option.Options(s.options).Unsharded()...)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := s.shards.LoadOrStore(shard, shardService)
+	return actual.(*Service), nil
+}
@@ -0,0 +1,77 @@
+package insert_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/option"
+	"path"
+	"testing"
+)
+
+func TestService_Exec_Shards(t *testing.T) {
+	type entity struct {
+		ID     int    `sqlx:"name=id,primaryKey=true"`
+		Tenant string `sqlx:"name=tenant"`
+	}
+	var testCases = []struct {
+		description string
+		records     []*entity
+		expect      map[string]int
+		expectErr   bool
+	}{
+		{
+			description: "records split by tenant shard",
+			records:     []*entity{{ID: 1, Tenant: "a"}, {ID: 2, Tenant: "b"}, {ID: 3, Tenant: "a"}, {ID: 4, Tenant: "c"}},
+			expect:      map[string]int{"shard_a": 2, "shard_b": 1, "shard_c": 1},
+		},
+		{
+			description: "unresolved shard",
+			records:     []*entity{{ID: 1, Tenant: "x"}},
+			expectErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		shards := map[string]*option.Shard{}
+		for _, name := range []string{"shard_a", "shard_b", "shard_c"} {
+			db, err := sql.Open("sqlite3", path.Join(t.TempDir(), name+".db"))
+			if !assert.Nil(t, err, testCase.description) {
+				return
+			}
+			defer db.Close()
+			_, err = db.Exec(fmt.Sprintf("CREATE TABLE entity_%v (id INTEGER PRIMARY KEY, tenant TEXT)", name[len(name)-1:]))
+			assert.Nil(t, err, testCase.description)
+			shards[name] = &option.Shard{DB: db, Suffix: "_" + name[len(name)-1:]}
+		}
+		resolver := option.ShardResolver(func(record interface{}) (*option.Shard, error) {
+			shard, ok := shards["shard_"+record.(*entity).Tenant]
+			if !ok {
+				return nil, fmt.Errorf("unknown tenant: %v", record.(*entity).Tenant)
+			}
+			return shard, nil
+		})
+		service, err := insert.New(context.TODO(), shards["shard_a"].DB, "entity", resolver)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, _, err := service.Exec(context.TODO(), testCase.records)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(testCase.records), affected, testCase.description)
+		for name, expect := range testCase.expect {
+			shard := shards[name]
+			var count int
+			err = shard.DB.QueryRow("SELECT COUNT(*) FROM entity" + shard.Suffix).Scan(&count)
+			assert.Nil(t, err, testCase.description)
+			assert.EqualValues(t, expect, count, testCase.description+" "+name)
+		}
+	}
+}
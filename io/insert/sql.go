@@ -3,8 +3,10 @@ package insert
 import (
 	"fmt"
 	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/hook"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
+	"os"
 	"strings"
 )
 
@@ -107,6 +109,19 @@ func estimateBufferSize(table string, columns []string, batchSize int) int {
 
 var showSQL bool
 
+// ShowSQL prints prepared SQL to stdout when no hooks were supplied with options
+//
+// Deprecated: use hook.NewPrinter(os.Stdout) or hook.NewSlog(logger, level) option instead
 func ShowSQL(b bool) {
 	showSQL = b
 }
+
+func sqlHooks(options []option.Option) *hook.Chain {
+	if hooks := option.Options(options).Hooks(); hooks != nil {
+		return hooks
+	}
+	if showSQL {
+		return hook.New(hook.NewPrinter(os.Stdout))
+	}
+	return nil
+}
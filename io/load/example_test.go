@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/load"
 	_ "github.com/viant/sqlx/metadata/product/mysql/load"
 	"log"
@@ -32,3 +33,33 @@ func ExampleService_Exec() {
 	}
 	fmt.Printf("loaded %v\n", count)
 }
+
+func ExampleService_Exec_stream() {
+	type Foo struct {
+		ID   int
+		Name string
+	}
+	dsn := ""
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	loader, err := load.New(context.Background(), db, "dest_table")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	records := make(chan *Foo)
+	go func() {
+		defer close(records)
+		//for _, record := range getAppData() { records <- record }
+	}()
+	stream, err := io.NewChanRecordStream(records)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	count, err := loader.Exec(context.TODO(), stream)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("loaded %v\n", count)
+}
@@ -24,12 +24,13 @@ type (
 		stringifierConfig   *io.StringifierConfig
 		objectStringifier   *io.ObjectStringifier
 		objectWritten       bool
+		stream              *io.RecordStream
 	}
 )
 
 // Read data into itemBuffer
 func (r *Reader) Read(buffer []byte) (n int, err error) {
-	if (r.isEOF || r.index >= r.itemCount) && r.itemBuffer.len() == 0 {
+	if r.isEOF && r.itemBuffer.len() == 0 {
 		return 0, goIo.EOF
 	}
 
@@ -52,16 +53,43 @@ func (r *Reader) Read(buffer []byte) (n int, err error) {
 				return r.offsetOfCurrentRead, nil
 			}
 		} else {
-			if r.index >= r.itemCount {
+			record, ok, err := r.nextRecord()
+			if err != nil {
+				return r.offsetOfCurrentRead, err
+			}
+			if !ok {
 				r.isEOF = true
+				if r.offsetOfCurrentRead == 0 {
+					return 0, goIo.EOF
+				}
 				return r.offsetOfCurrentRead, nil
 			}
-			r.fillItemBuffer(r.index)
-			r.index++
+			r.fillItemBuffer(record)
 		}
 	}
 }
 
+func (r *Reader) nextRecord() (interface{}, bool, error) {
+	if r.stream != nil {
+		record, err := r.stream.Next()
+		if err == goIo.EOF {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		r.itemCount = r.stream.Count()
+		return record, true, nil
+	}
+
+	if r.index >= r.itemCount {
+		return nil, false, nil
+	}
+	record := r.valueAt(r.index)
+	r.index++
+	return record, true, nil
+}
+
 func (r *Reader) init() error {
 	if r.initialized {
 		return nil
@@ -76,8 +104,7 @@ func (r *Reader) init() error {
 }
 
 // fillItemBuffer stringifies and reads data into r.itemBuffer, separates objects and fields values with given separators.
-func (r *Reader) fillItemBuffer(i int) {
-	record := r.valueAt(i)
+func (r *Reader) fillItemBuffer(record interface{}) {
 	stringifiedFieldValues, wasString := r.stringifier(record)
 
 	r.writeObject(stringifiedFieldValues, wasString)
@@ -140,6 +167,34 @@ func NewReader(any interface{}, config *Config, options ...interface{}) (*Reader
 	}
 
 	structType := io.EnsureDereference(valueAt(0))
+	r, err := newReader(structType, config, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.valueAt = valueAt
+	r.itemCount = size
+	return r, structType, nil
+}
+
+// NewStreamReader returns Reader instance for streamed records and actual data struct type,
+// records are stringified only when read, so memory stays flat regardless of the stream size.
+func NewStreamReader(stream *io.RecordStream, config *Config, options ...interface{}) (*Reader, reflect.Type, error) {
+	structType := stream.StructType()
+	if structType == nil {
+		return nil, nil, fmt.Errorf("sqlx io load reader csv newstreamreader: unable to create reader - record type was empty")
+	}
+
+	r, err := newReader(structType, config, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.stream = stream
+	return r, structType, nil
+}
+
+func newReader(structType reflect.Type, config *Config, options []interface{}) (*Reader, error) {
 	stringifier, stringifierConfig := readOptions(options)
 	if stringifier == nil {
 		stringifier = io.TypeStringifier(structType, config.NullValue, true)
@@ -148,20 +203,16 @@ func NewReader(any interface{}, config *Config, options ...interface{}) (*Reader
 	stringifierFn, err := stringifier.Stringifier(options...)
 
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	r := &Reader{
+	return &Reader{
 		objectStringifier: stringifier,
 		config:            config,
-		valueAt:           valueAt,
-		itemCount:         size,
 		stringifier:       stringifierFn,
 		itemBuffer:        NewBuffer(1024),
 		stringifierConfig: stringifierConfig,
-	}
-
-	return r, structType, nil
+	}, nil
 }
 
 func readOptions(options []interface{}) (*io.ObjectStringifier, *io.StringifierConfig) {
@@ -184,7 +235,7 @@ func readOptions(options []interface{}) (*io.ObjectStringifier, *io.StringifierC
 	return stringifier, stringifierConfig
 }
 
-// ItemCount returns count of items inside itemBuffer
+// ItemCount returns count of items inside itemBuffer, for streamed records it returns count of records read so far
 func (r *Reader) ItemCount() int {
 	return r.itemCount
 }
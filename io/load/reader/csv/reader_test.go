@@ -1070,3 +1070,40 @@ func float64Ptr(i float64) *float64 {
 func timePtr(i time.Time) *time.Time {
 	return &i
 }
+
+func TestStreamReader(t *testing.T) {
+	type Foo struct {
+		ID   int
+		Name string
+	}
+	config := &Config{
+		FieldSeparator:  `,`,
+		ObjectSeparator: `#`,
+		EncloseBy:       `'`,
+		EscapeBy:        `\`,
+		NullValue:       "null",
+	}
+
+	records := make(chan *Foo)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			records <- &Foo{ID: i, Name: "name " + string(rune('A'+i-1))}
+		}
+		close(records)
+	}()
+
+	stream, err := io.NewChanRecordStream(records)
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, dataType, err := NewStreamReader(stream, config)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "Foo", dataType.Name())
+
+	actual, err := goIo.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, `1,'name A'#2,'name B'#3,'name C'`, string(actual))
+	assert.Equal(t, 3, reader.ItemCount())
+}
@@ -22,3 +22,27 @@ func NewReader(any interface{}) (goIo.Reader, error) {
 	}
 	return buffer, nil
 }
+
+// NewStreamReader returns ReadCloser instance which encodes streamed records as newline delimited json through a pipe,
+// caller has to close it to release encoding goroutine
+func NewStreamReader(stream *io.RecordStream) goIo.ReadCloser {
+	reader, writer := goIo.Pipe()
+	go func() {
+		enc := json.NewEncoder(writer)
+		for {
+			record, err := stream.Next()
+			if err == goIo.EOF {
+				_ = writer.Close()
+				return
+			}
+			if err == nil {
+				err = enc.Encode(record)
+			}
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return reader
+}
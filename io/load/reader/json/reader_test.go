@@ -1,10 +1,13 @@
 package json
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
 	goIo "io"
 	"log"
 	"testing"
+	"time"
 )
 
 type Foo struct {
@@ -84,3 +87,33 @@ func TestReader_Read(t *testing.T) {
 		}
 	}
 }
+
+func TestNewStreamReader(t *testing.T) {
+	records := []*Foo{{ID: 1, Total: 7.1, Desc01: "A1"}, {ID: 2, Total: 7.2, Desc01: "A2"}}
+	stream, err := io.StreamOf(records)
+	if !assert.Nil(t, err) {
+		return
+	}
+	actual, err := goIo.ReadAll(NewStreamReader(stream))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"ID":1,"Total":7.1,"Desc01":"A1"}
+{"ID":2,"Total":7.2,"Desc01":"A2"}
+`, string(actual))
+
+	failing := io.NewRecordStream(nil, func() (interface{}, error) {
+		return nil, fmt.Errorf("source failure")
+	})
+	_, err = goIo.ReadAll(NewStreamReader(failing))
+	assert.EqualError(t, err, "source failure")
+
+	calls := make(chan bool, 10)
+	endless := io.NewRecordStream(nil, func() (interface{}, error) {
+		calls <- true
+		return &Foo{ID: 1}, nil
+	})
+	reader := NewStreamReader(endless)
+	time.Sleep(20 * time.Millisecond) //encoding goroutine blocks on pipe write till reader is read or closed
+	assert.Nil(t, reader.Close())
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, calls, 1, "encoding goroutine should stop once reader is closed")
+}
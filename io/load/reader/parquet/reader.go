@@ -15,10 +15,7 @@ func NewReader(any interface{}) (goIo.Reader, error) {
 	}
 
 	buf := new(bytes.Buffer)
-	writerConfig := aParquet.WriterConfig{
-		Compression: &aParquet.Zstd,
-	}
-	writer := aParquet.NewWriter(buf, &writerConfig)
+	writer := aParquet.NewWriter(buf, writerConfig())
 
 	for i := 0; i < size; i++ {
 		err = writer.Write(valueAt(i)) // func Write adds '\n'
@@ -35,3 +32,39 @@ func NewReader(any interface{}) (goIo.Reader, error) {
 
 	return buf, nil
 }
+
+// NewStreamReader returns ReadCloser instance which encodes streamed records in parquet format through a pipe,
+// caller has to close it to release encoding goroutine
+func NewStreamReader(stream *io.RecordStream) goIo.ReadCloser {
+	reader, pipeWriter := goIo.Pipe()
+	go func() {
+		writer := aParquet.NewWriter(pipeWriter, writerConfig())
+		err := writeStream(writer, stream)
+		if err == nil {
+			err = writer.Close()
+		}
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	return reader
+}
+
+func writeStream(writer *aParquet.Writer, stream *io.RecordStream) error {
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = writer.Write(record); err != nil {
+			return err
+		}
+	}
+}
+
+func writerConfig() *aParquet.WriterConfig {
+	return &aParquet.WriterConfig{
+		Compression: &aParquet.Zstd,
+	}
+}
@@ -0,0 +1,73 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/retry"
+	"github.com/viant/sqlx/loption"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	_ "github.com/viant/sqlx/metadata/product/sqlite/load"
+	goIo "io"
+	"path"
+	"reflect"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestService_Exec_RetryStream(t *testing.T) {
+	type Foo struct {
+		ID   int    `sqlx:"ID,primaryKey"`
+		Name string `sqlx:"NAME"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "retry.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE FOO (ID INTEGER PRIMARY KEY, NAME TEXT)")
+	if !assert.Nil(t, err) {
+		return
+	}
+	loader, err := New(context.Background(), db, "FOO")
+	if !assert.Nil(t, err) {
+		return
+	}
+	streamErr := errors.New("stream interrupted")
+	policy := retry.New(3, 0)
+	policy.Retryable = func(err error) bool { return true }
+
+	var testCases = []struct {
+		description string
+		data        func(calls *int) interface{}
+		expectErr   error
+		expectCalls int
+	}{
+		{
+			description: "record stream is not retried",
+			data: func(calls *int) interface{} {
+				return io.NewRecordStream(reflect.TypeOf(&Foo{}), func() (interface{}, error) {
+					*calls++
+					if *calls == 1 {
+						return &Foo{ID: 1, Name: "a"}, nil
+					}
+					if *calls == 2 {
+						return nil, streamErr
+					}
+					return nil, goIo.EOF
+				})
+			},
+			expectErr:   streamErr,
+			expectCalls: 2,
+		},
+	}
+	for _, testCase := range testCases {
+		calls := 0
+		_, err := loader.Exec(context.Background(), testCase.data(&calls), loption.WithRetryPolicy(policy))
+		assert.ErrorIs(t, err, testCase.expectErr, testCase.description)
+		assert.EqualValues(t, testCase.expectCalls, calls, testCase.description)
+	}
+}
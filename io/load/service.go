@@ -4,10 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/io/router"
 	"github.com/viant/sqlx/loption"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
+	"sync"
 )
 
 // Service represents service used to
@@ -16,6 +21,7 @@ type Service struct {
 	tableName string
 	columns   []sink.Column
 	db        *sql.DB
+	shards    sync.Map
 }
 
 // New creates instance of Service
@@ -33,8 +39,32 @@ func New(ctx context.Context, db *sql.DB, tableName string) (*Service, error) {
 
 }
 
-// Exec executes load statement specific for database
+// Exec executes load statement specific for database, retry policy option re-runs the whole service owned transaction
+// on transient failure, streamed records (io.RecordStream, io.EncodedStream) are loaded without retry, shard resolver option splits records by shard and loads
+// shard groups in parallel
 func (s *Service) Exec(ctx context.Context, any interface{}, options ...loption.Option) (int, error) {
+	ctx = router.WithPrimary(ctx)
+	loadOptions := loption.NewOptions(options...)
+	if resolver := loadOptions.GetShardResolver(); resolver != nil {
+		return s.execShards(ctx, resolver, any, options...)
+	}
+	return s.execWithRetry(ctx, loadOptions, any, options...)
+}
+
+func (s *Service) execWithRetry(ctx context.Context, loadOptions *loption.Options, any interface{}, options ...loption.Option) (int, error) {
+	policy := loadOptions.GetRetryPolicy()
+	if policy == nil || isStream(any) { //consumed stream can not be re-read by retry attempt
+		return s.exec(ctx, any, options...)
+	}
+	var affected int
+	err := policy.RunObserved(ctx, loadOptions.GetCommonOptions().Collector(), s.labels(), func(ctx context.Context) (err error) {
+		affected, err = s.exec(ctx, any, options...)
+		return err
+	})
+	return affected, err
+}
+
+func (s *Service) exec(ctx context.Context, any interface{}, options ...loption.Option) (int, error) {
 	dialect, err := s.ensureDialect(ctx)
 	if err != nil {
 		return 0, err
@@ -44,15 +74,37 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...loption.
 		return 0, fmt.Errorf("failed to lookup load session for dialect %v", dialect.Name)
 	}
 
+	commonOptions := loption.NewOptions(options...).GetCommonOptions()
+	ctx, done := commonOptions.Hooks().Start(ctx, &hook.Event{Op: hook.OpExec, Source: "load", Table: s.tableName})
 	exec, err := session.Exec(ctx, any, s.db, s.tableName, options...)
 	if err != nil {
+		done(0, err)
 		return 0, err
 	}
 
 	affected, err := exec.RowsAffected()
+	done(affected, err)
+	if collector := commonOptions.Collector(); collector != nil {
+		if loaded, ok := exec.(interface{ BytesLoaded() int64 }); ok && loaded.BytesLoaded() > 0 {
+			collector.Count(metrics.LoadedBytes, s.labels(), float64(loaded.BytesLoaded()))
+		}
+	}
 	return int(affected), err
 }
 
+// isStream returns true if data is streamed records or encoded reader
+func isStream(any interface{}) bool {
+	switch any.(type) {
+	case *io.RecordStream, *io.EncodedStream:
+		return true
+	}
+	return false
+}
+
+func (s *Service) labels() metrics.Labels {
+	return metrics.Labels{Source: "load", Table: s.tableName, Op: "exec"}
+}
+
 func (s *Service) ensureDialect(ctx context.Context) (*info.Dialect, error) {
 	if s.dialect != nil {
 		return s.dialect, nil
@@ -0,0 +1,40 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/loption"
+	"github.com/viant/sqlx/option"
+	"sync"
+)
+
+// execShards splits records by shard and loads shard groups in parallel
+func (s *Service) execShards(ctx context.Context, resolver option.ShardResolver, any interface{}, options ...loption.Option) (int, error) {
+	loadOptions := loption.NewOptions(options...)
+	if loadOptions.GetTransaction() != nil {
+		return 0, fmt.Errorf("shard resolver can not be used with global transaction")
+	}
+	groups, err := io.GroupByShard(any, resolver)
+	if err != nil {
+		return 0, err
+	}
+	var affected int
+	var mux sync.Mutex
+	err = io.RunShards(groups, func(group *io.ShardGroup) error {
+		count, err := s.shardService(group.Shard).execWithRetry(ctx, loadOptions, group.Records, options...)
+		mux.Lock()
+		affected += count
+		mux.Unlock()
+		return err
+	})
+	return affected, err
+}
+
+func (s *Service) shardService(shard option.Shard) *Service {
+	if cached, ok := s.shards.Load(shard); ok {
+		return cached.(*Service)
+	}
+	actual, _ := s.shards.LoadOrStore(shard, &Service{tableName: s.tableName + shard.Suffix, db: shard.DB, dialect: s.dialect})
+	return actual.(*Service)
+}
@@ -69,6 +69,7 @@ type ColumnMapper func(src interface{}, options ...option.Option) ([]Column, Pla
 type columnMapperBuilder struct {
 	columns              []ColumnWithFields
 	identityColumns      []ColumnWithFields
+	tenantColumns        []ColumnWithFields
 	setMarker            *option.SetMarker
 	columnRestriction    option.ColumnRestriction
 	identityOnly         bool
@@ -284,6 +285,13 @@ func (b *columnMapperBuilder) appendColumns(field reflect.StructField, caseForma
 		b.structOrderedColumns = append(b.structOrderedColumns, col)
 		return nil
 	}
+	if tag.Tenant {
+		tag.Column = columnName
+		col := NewColumnWithFields(columnName, tag.DataType, field.Type, holders, WithTag(tag))
+		b.tenantColumns = append(b.tenantColumns, col)
+		b.structOrderedColumns = append(b.structOrderedColumns, col)
+		return nil
+	}
 	if b.identityOnly {
 		return nil
 	}
@@ -296,10 +304,11 @@ func (b *columnMapperBuilder) appendColumns(field reflect.StructField, caseForma
 }
 
 func (b *columnMapperBuilder) mergeColumns() []ColumnWithFields {
-	//make sure identity columns are at the end
+	//make sure identity columns are at the end, followed by tenant columns
 	var columns []ColumnWithFields
 	columns = append(columns, b.columns...)
 	columns = append(columns, b.identityColumns...)
+	columns = append(columns, b.tenantColumns...)
 
 	return columns
 }
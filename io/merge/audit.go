@@ -0,0 +1,67 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/audit"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+)
+
+// auditor captures merged rows images by source record identity
+type auditor struct {
+	recorder *audit.Recorder
+	keys     [][]interface{}
+	before   []map[string]interface{}
+}
+
+func newAuditor(ctx context.Context, trail *audit.Trail, table string, dialect *info.Dialect, db audit.DB, any interface{}) (*auditor, error) {
+	valueAt, count, err := io.Values(any)
+	if err != nil || count == 0 {
+		return nil, err
+	}
+	columns, binder, err := io.StructColumnMapper(valueAt(0), option.IdentityOnly(true))
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("failed to audit %v merge: record %T has no identity columns", table, valueAt(0))
+	}
+	result := &auditor{recorder: trail.Recorder(table, io.Columns(columns).Names(), dialect, db)}
+	for i := 0; i < count; i++ {
+		key := make([]interface{}, len(columns))
+		binder(valueAt(i), key, 0, len(columns))
+		result.keys = append(result.keys, key)
+	}
+	if result.before, err = result.recorder.FetchAll(ctx, result.keys); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// record fetches post-images, records changes and writes them to the trail sink
+func (a *auditor) record(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+	images, err := a.recorder.FetchAll(ctx, a.keys)
+	if err != nil {
+		return err
+	}
+	for i, key := range a.keys {
+		before, after := a.before[i], images[i]
+		switch {
+		case before == nil && after == nil:
+		case before == nil:
+			a.recorder.Add(ctx, audit.OpInsert, key, nil, after, audit.Diff(nil, after))
+		case after == nil:
+			a.recorder.Add(ctx, audit.OpDelete, key, before, nil, nil)
+		default:
+			if columns := audit.Diff(before, after); len(columns) > 0 {
+				a.recorder.Add(ctx, audit.OpUpdate, key, before, after, columns)
+			}
+		}
+	}
+	return a.recorder.Flush(ctx)
+}
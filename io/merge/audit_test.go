@@ -0,0 +1,105 @@
+package merge
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/audit"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/moption"
+	"path"
+	"testing"
+)
+
+func TestAuditor_Record(t *testing.T) {
+	type item struct {
+		ID   int    `sqlx:"id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "merge.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT); INSERT INTO items VALUES (1, 'a'), (2, 'b'), (3, 'c')")
+	if !assert.Nil(t, err) {
+		return
+	}
+	var actual = map[int]*audit.Change{}
+	trail := audit.New(audit.Func(func(ctx context.Context, changes []*audit.Change) error {
+		for _, change := range changes {
+			actual[change.Key["id"].(int)] = change
+		}
+		return nil
+	}))
+	ctx := context.Background()
+	changes, err := newAuditor(ctx, trail, "items", nil, db, []*item{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = db.Exec("UPDATE items SET name = 'x' WHERE id = 1; DELETE FROM items WHERE id = 2; INSERT INTO items VALUES (4, 'd')")
+	assert.Nil(t, err)
+	assert.Nil(t, changes.record(ctx))
+
+	var testCases = []struct {
+		description string
+		id          int
+		expect      audit.Operation
+		columns     []string
+	}{
+		{description: "updated row", id: 1, expect: audit.OpUpdate, columns: []string{"name"}},
+		{description: "deleted row", id: 2, expect: audit.OpDelete},
+		{description: "inserted row", id: 4, expect: audit.OpInsert, columns: []string{"id", "name"}},
+	}
+	for _, testCase := range testCases {
+		change, ok := actual[testCase.id]
+		if !assert.True(t, ok, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, change.Operation, testCase.description)
+		assert.EqualValues(t, testCase.columns, change.Columns, testCase.description)
+	}
+	assert.Len(t, actual, 3, "unchanged row")
+}
+
+func TestService_AuditTransaction(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "merge_tx.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	service, err := New(context.Background(), db, "items")
+	if !assert.Nil(t, err) {
+		return
+	}
+	dialect, err := service.ensureDialect(context.Background())
+	if !assert.Nil(t, err) {
+		return
+	}
+	tx, err := db.Begin()
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tx.Rollback()
+
+	var testCases = []struct {
+		description  string
+		options      []moption.Option
+		expectGlobal bool
+	}{
+		{description: "service owned transaction"},
+		{description: "supplied transaction", options: []moption.Option{moption.WithTransaction(tx)}, expectGlobal: true},
+	}
+	for _, testCase := range testCases {
+		transaction, err := service.auditTransaction(context.Background(), dialect, moption.NewOptions(testCase.options...))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectGlobal, transaction.Global, testCase.description)
+		if testCase.expectGlobal {
+			assert.Equal(t, tx, transaction.Tx, testCase.description)
+		}
+		assert.Nil(t, transaction.Rollback(), testCase.description)
+	}
+}
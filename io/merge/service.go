@@ -3,10 +3,16 @@ package merge
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/io/router"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/moption"
+	"github.com/viant/sqlx/option"
 )
 
 // Service represents merge service
@@ -31,8 +37,25 @@ func New(ctx context.Context, db *sql.DB, table string) (*Service, error) {
 	}, nil
 }
 
-// Exec performs database-specific merge operations
+// Exec performs database-specific merge operations, audit trail option captures merged rows images by source record identity
+// within supplied or service owned transaction shared with merge and change records, retry policy option re-runs the whole service owned transaction on transient failure
 func (s *Service) Exec(ctx context.Context, any interface{}, mConfig info.MergeConfig, options ...moption.Option) (info.MergeResult, error) {
+	ctx = router.WithPrimary(ctx)
+	mergeOptions := moption.NewOptions(options...)
+	policy := mergeOptions.GetRetryPolicy()
+	if policy == nil {
+		return s.exec(ctx, any, mConfig, options...)
+	}
+	var result info.MergeResult
+	labels := metrics.Labels{Source: "merge", Table: s.tableName, Op: "exec"}
+	err := policy.RunObserved(ctx, mergeOptions.GetCommonOptions().Collector(), labels, func(ctx context.Context) (err error) {
+		result, err = s.exec(ctx, any, mConfig, options...)
+		return err
+	})
+	return result, err
+}
+
+func (s *Service) exec(ctx context.Context, any interface{}, mConfig info.MergeConfig, options ...moption.Option) (info.MergeResult, error) {
 	dialect, err := s.ensureDialect(ctx)
 	if err != nil {
 		return nil, err
@@ -42,7 +65,52 @@ func (s *Service) Exec(ctx context.Context, any interface{}, mConfig info.MergeC
 		return nil, err
 	}
 
-	return executor.Exec(ctx, any, s.db, s.tableName, options...)
+	mergeOptions := moption.NewOptions(options...)
+	var changes *auditor
+	var transaction *io.Transaction
+	if trail := mergeOptions.GetAuditTrail(); trail != nil {
+		if transaction, err = s.auditTransaction(ctx, dialect, mergeOptions); err != nil {
+			return nil, err
+		}
+		options = append(options, moption.WithTransaction(transaction.Tx))
+		if changes, err = newAuditor(ctx, trail, s.tableName, dialect, transaction.Tx, any); err != nil {
+			return nil, transaction.RollbackWithErr(err)
+		}
+	}
+	hooks := mergeOptions.GetCommonOptions().Hooks()
+	ctx, done := hooks.Start(ctx, &hook.Event{Op: hook.OpExec, Source: "merge", Table: s.tableName})
+	result, err := executor.Exec(ctx, any, s.db, s.tableName, options...)
+	var affected int64
+	if err == nil && result != nil {
+		affected = int64(result.RowsAffected())
+	}
+	done(affected, err)
+	if err == nil {
+		err = changes.record(ctx)
+	}
+	if transaction != nil {
+		if err != nil {
+			return result, transaction.RollbackWithErr(err)
+		}
+		err = transaction.Commit()
+	}
+	return result, err
+}
+
+// auditTransaction returns supplied or service owned transaction, audit images, merge and change records share it
+func (s *Service) auditTransaction(ctx context.Context, dialect *info.Dialect, mergeOptions *moption.Options) (*io.Transaction, error) {
+	var options = append(option.Options{}, mergeOptions.GetCommonOptions()...)
+	if tx := mergeOptions.GetTransaction(); tx != nil {
+		options = append(option.Options{tx}, options...)
+	}
+	transaction, err := io.TransactionFor(ctx, dialect, s.db, options)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, fmt.Errorf("failed to audit %v merge: %v dialect is not transactional", s.tableName, dialect.Name)
+	}
+	return transaction, nil
 }
 
 func (s *Service) ensureDialect(ctx context.Context) (*info.Dialect, error) {
@@ -0,0 +1,32 @@
+package metrics
+
+// Metric names emitted by io services
+const (
+	Statements        = "sqlx_statements_total"
+	StatementErrors   = "sqlx_statement_errors_total"
+	StatementDuration = "sqlx_statement_duration_seconds"
+	Rows              = "sqlx_rows_total"
+	Batches           = "sqlx_batches_total"
+	BatchSize         = "sqlx_batch_size"
+	LoadedBytes       = "sqlx_loaded_bytes_total"
+	CacheHits         = "sqlx_cache_hits_total"
+	CacheMisses       = "sqlx_cache_misses_total"
+	Retries           = "sqlx_retries_total"
+)
+
+type (
+	// Labels represents metric labels
+	Labels struct {
+		Source string //originating service: read, insert, batcher, update, delete, load, merge, metadata
+		Table  string
+		Op     string
+	}
+
+	// Collector represents metrics collector
+	Collector interface {
+		//Count adds delta to counter
+		Count(name string, labels Labels, delta float64)
+		//Observe records histogram observation
+		Observe(name string, labels Labels, value float64)
+	}
+)
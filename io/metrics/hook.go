@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+	"github.com/viant/sqlx/io/hook"
+)
+
+// Hook represents hook reporting statements, rows, errors and durations to collector
+type Hook struct {
+	collector Collector
+}
+
+// Before returns unchanged context
+func (h *Hook) Before(ctx context.Context, event *hook.Event) context.Context {
+	return ctx
+}
+
+// After reports completed operation
+func (h *Hook) After(ctx context.Context, event *hook.Event) {
+	labels := Labels{Source: event.Source, Table: event.Table, Op: string(event.Op)}
+	h.collector.Count(Statements, labels, 1)
+	h.collector.Observe(StatementDuration, labels, event.Duration.Seconds())
+	if event.RowsAffected > 0 {
+		h.collector.Count(Rows, labels, float64(event.RowsAffected))
+	}
+	if event.Err != nil {
+		h.collector.Count(StatementErrors, labels, 1)
+	}
+}
+
+// NewHook creates collector hook
+func NewHook(collector Collector) *Hook {
+	return &Hook{collector: collector}
+}
@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	// DurationBuckets represents default duration histogram buckets in seconds
+	DurationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}
+	// SizeBuckets represents default size histogram buckets
+	SizeBuckets = []float64{1, 10, 50, 100, 500, 1000, 5000, 10000}
+)
+
+type (
+	// Sample represents counter value
+	Sample struct {
+		Name   string
+		Labels Labels
+		Value  float64
+	}
+
+	// Histogram represents histogram snapshot, Counts are cumulative counts for corresponding Buckets upper bounds
+	Histogram struct {
+		Name    string
+		Labels  Labels
+		Buckets []float64
+		Counts  []uint64
+		Count   uint64
+		Sum     float64
+	}
+
+	// Memory represents in memory collector
+	Memory struct {
+		counters   map[key]*Sample
+		histograms map[key]*Histogram
+		buckets    map[string][]float64
+		mux        sync.RWMutex
+	}
+
+	key struct {
+		name string
+		Labels
+	}
+)
+
+// WithBuckets sets histogram buckets for metric name
+func (m *Memory) WithBuckets(name string, buckets []float64) *Memory {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+	m.buckets[name] = sorted
+	return m
+}
+
+// Count adds delta to counter
+func (m *Memory) Count(name string, labels Labels, delta float64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	aKey := key{name: name, Labels: labels}
+	sample, ok := m.counters[aKey]
+	if !ok {
+		sample = &Sample{Name: name, Labels: labels}
+		m.counters[aKey] = sample
+	}
+	sample.Value += delta
+}
+
+// Observe records histogram observation
+func (m *Memory) Observe(name string, labels Labels, value float64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	aKey := key{name: name, Labels: labels}
+	histogram, ok := m.histograms[aKey]
+	if !ok {
+		buckets := m.bucketsFor(name)
+		histogram = &Histogram{Name: name, Labels: labels, Buckets: buckets, Counts: make([]uint64, len(buckets))}
+		m.histograms[aKey] = histogram
+	}
+	for i, bound := range histogram.Buckets {
+		if value <= bound {
+			histogram.Counts[i]++
+		}
+	}
+	histogram.Count++
+	histogram.Sum += value
+}
+
+// Counter returns counter value
+func (m *Memory) Counter(name string, labels Labels) float64 {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	if sample, ok := m.counters[key{name: name, Labels: labels}]; ok {
+		return sample.Value
+	}
+	return 0
+}
+
+// Histogram returns histogram snapshot or nil
+func (m *Memory) Histogram(name string, labels Labels) *Histogram {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	if histogram, ok := m.histograms[key{name: name, Labels: labels}]; ok {
+		return histogram.clone()
+	}
+	return nil
+}
+
+// Counters returns counters snapshot sorted by name and labels
+func (m *Memory) Counters() []*Sample {
+	m.mux.RLock()
+	result := make([]*Sample, 0, len(m.counters))
+	for _, sample := range m.counters {
+		clone := *sample
+		result = append(result, &clone)
+	}
+	m.mux.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Name, result[i].Labels, result[j].Name, result[j].Labels)
+	})
+	return result
+}
+
+// Histograms returns histograms snapshot sorted by name and labels
+func (m *Memory) Histograms() []*Histogram {
+	m.mux.RLock()
+	result := make([]*Histogram, 0, len(m.histograms))
+	for _, histogram := range m.histograms {
+		result = append(result, histogram.clone())
+	}
+	m.mux.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i].Name, result[i].Labels, result[j].Name, result[j].Labels)
+	})
+	return result
+}
+
+// Reset removes all collected metrics
+func (m *Memory) Reset() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.counters = map[key]*Sample{}
+	m.histograms = map[key]*Histogram{}
+}
+
+func (m *Memory) bucketsFor(name string) []float64 {
+	if buckets, ok := m.buckets[name]; ok {
+		return buckets
+	}
+	if name == BatchSize {
+		return SizeBuckets
+	}
+	return DurationBuckets
+}
+
+func (h *Histogram) clone() *Histogram {
+	result := *h
+	result.Counts = append([]uint64{}, h.Counts...)
+	return &result
+}
+
+func less(name string, labels Labels, otherName string, other Labels) bool {
+	if name != otherName {
+		return name < otherName
+	}
+	if labels.Source != other.Source {
+		return labels.Source < other.Source
+	}
+	if labels.Table != other.Table {
+		return labels.Table < other.Table
+	}
+	return labels.Op < other.Op
+}
+
+// NewMemory creates in memory collector
+func NewMemory() *Memory {
+	return &Memory{
+		counters:   map[key]*Sample{},
+		histograms: map[key]*Histogram{},
+		buckets:    map[string][]float64{},
+	}
+}
@@ -0,0 +1,89 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/io/read"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/option"
+	"path"
+	"testing"
+)
+
+func TestMemory_WritePrometheus(t *testing.T) {
+	collector := metrics.NewMemory().WithBuckets(metrics.StatementDuration, []float64{0.5, 0.1})
+	insertLabels := metrics.Labels{Source: "insert", Table: "foo", Op: "exec"}
+	collector.Count(metrics.Statements, insertLabels, 1)
+	collector.Count(metrics.Statements, insertLabels, 2)
+	collector.Count(metrics.Rows, metrics.Labels{Source: "read", Table: `a"b`}, 10)
+	collector.Observe(metrics.StatementDuration, insertLabels, 0.05)
+	collector.Observe(metrics.StatementDuration, insertLabels, 0.2)
+	collector.Observe(metrics.StatementDuration, insertLabels, 3)
+
+	assert.EqualValues(t, 3, collector.Counter(metrics.Statements, insertLabels))
+	histogram := collector.Histogram(metrics.StatementDuration, insertLabels)
+	if assert.NotNil(t, histogram) {
+		assert.EqualValues(t, []uint64{1, 2}, histogram.Counts)
+		assert.EqualValues(t, 3, histogram.Count)
+	}
+
+	buffer := new(bytes.Buffer)
+	assert.Nil(t, collector.WritePrometheus(buffer))
+	expect := `# TYPE sqlx_rows_total counter
+sqlx_rows_total{source="read",table="a\"b"} 10
+# TYPE sqlx_statements_total counter
+sqlx_statements_total{source="insert",table="foo",op="exec"} 3
+# TYPE sqlx_statement_duration_seconds histogram
+sqlx_statement_duration_seconds_bucket{source="insert",table="foo",op="exec",le="0.1"} 1
+sqlx_statement_duration_seconds_bucket{source="insert",table="foo",op="exec",le="0.5"} 2
+sqlx_statement_duration_seconds_bucket{source="insert",table="foo",op="exec",le="+Inf"} 3
+sqlx_statement_duration_seconds_sum{source="insert",table="foo",op="exec"} 3.25
+sqlx_statement_duration_seconds_count{source="insert",table="foo",op="exec"} 3
+`
+	assert.EqualValues(t, expect, buffer.String())
+}
+
+func TestCollector_Services(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,primaryKey=true"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "metrics.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE metric_entity (id INTEGER PRIMARY KEY, name TEXT)")
+	if !assert.Nil(t, err) {
+		return
+	}
+	collector := metrics.NewMemory()
+	inserter, err := insert.New(context.TODO(), db, "metric_entity")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = inserter.Exec(context.TODO(), []*entity{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}, option.BatchSize(2), collector)
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, err := read.New(context.TODO(), db, "SELECT id, name FROM metric_entity", func() interface{} { return &entity{} }, read.WithCollector(collector))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Nil(t, reader.QueryAll(context.TODO(), func(row interface{}) error { return nil }))
+
+	insertLabels := metrics.Labels{Source: "insert", Table: "metric_entity", Op: "exec"}
+	assert.EqualValues(t, 2, collector.Counter(metrics.Statements, insertLabels))
+	assert.EqualValues(t, 3, collector.Counter(metrics.Rows, insertLabels))
+	assert.EqualValues(t, 1, collector.Counter(metrics.Statements, metrics.Labels{Op: "commit"}))
+	assert.EqualValues(t, 1, collector.Counter(metrics.Statements, metrics.Labels{Source: "read", Op: "query"}))
+	assert.EqualValues(t, 3, collector.Counter(metrics.Rows, metrics.Labels{Source: "read", Op: "query"}))
+	if histogram := collector.Histogram(metrics.StatementDuration, insertLabels); assert.NotNil(t, histogram) {
+		assert.EqualValues(t, 2, histogram.Count)
+	}
+}
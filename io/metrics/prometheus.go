@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus writes collected metrics in Prometheus text exposition format
+func (m *Memory) WritePrometheus(writer io.Writer) error {
+	buffer := bufio.NewWriter(writer)
+	name := ""
+	for _, sample := range m.Counters() {
+		if sample.Name != name {
+			name = sample.Name
+			writeType(buffer, name, "counter")
+		}
+		writeSample(buffer, name, sample.Labels, "", sample.Value)
+	}
+	name = ""
+	for _, histogram := range m.Histograms() {
+		if histogram.Name != name {
+			name = histogram.Name
+			writeType(buffer, name, "histogram")
+		}
+		for i, bound := range histogram.Buckets {
+			writeSample(buffer, name+"_bucket", histogram.Labels, formatFloat(bound), float64(histogram.Counts[i]))
+		}
+		writeSample(buffer, name+"_bucket", histogram.Labels, "+Inf", float64(histogram.Count))
+		writeSample(buffer, name+"_sum", histogram.Labels, "", histogram.Sum)
+		writeSample(buffer, name+"_count", histogram.Labels, "", float64(histogram.Count))
+	}
+	return buffer.Flush()
+}
+
+// ServeHTTP serves collected metrics in Prometheus text exposition format
+func (m *Memory) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := m.WritePrometheus(writer); err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeType(buffer *bufio.Writer, name, kind string) {
+	buffer.WriteString("# TYPE ")
+	buffer.WriteString(name)
+	buffer.WriteByte(' ')
+	buffer.WriteString(kind)
+	buffer.WriteByte('\n')
+}
+
+func writeSample(buffer *bufio.Writer, name string, labels Labels, le string, value float64) {
+	buffer.WriteString(name)
+	var pairs []string
+	pairs = appendLabel(pairs, "source", labels.Source)
+	pairs = appendLabel(pairs, "table", labels.Table)
+	pairs = appendLabel(pairs, "op", labels.Op)
+	pairs = appendLabel(pairs, "le", le)
+	if len(pairs) > 0 {
+		buffer.WriteByte('{')
+		buffer.WriteString(strings.Join(pairs, ","))
+		buffer.WriteByte('}')
+	}
+	buffer.WriteByte(' ')
+	buffer.WriteString(formatFloat(value))
+	buffer.WriteByte('\n')
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func appendLabel(pairs []string, name, value string) []string {
+	if value == "" {
+		return pairs
+	}
+	return append(pairs, name+`="`+labelEscaper.Replace(value)+`"`)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
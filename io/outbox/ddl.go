@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/metadata/info"
+	"strings"
+	"sync"
+)
+
+// ddls represents product outbox table DDL templates, %[1]v is replaced with table name, %[2]v with index name
+var ddls = map[string][]string{
+	"MySQL": {
+		"CREATE TABLE IF NOT EXISTS %[1]v (ID BIGINT AUTO_INCREMENT PRIMARY KEY, AGGREGATE_ID VARCHAR(255) NOT NULL, EVENT_TYPE VARCHAR(255) NOT NULL, PAYLOAD JSON NOT NULL, CREATED TIMESTAMP(6) NOT NULL, DISPATCHED TIMESTAMP(6) NULL, INDEX %[2]v (DISPATCHED, ID))",
+	},
+	"PostgreSQL": {
+		"CREATE TABLE IF NOT EXISTS %[1]v (ID BIGSERIAL PRIMARY KEY, AGGREGATE_ID VARCHAR(255) NOT NULL, EVENT_TYPE VARCHAR(255) NOT NULL, PAYLOAD JSONB NOT NULL, CREATED TIMESTAMP NOT NULL, DISPATCHED TIMESTAMP NULL)",
+		"CREATE INDEX IF NOT EXISTS %[2]v ON %[1]v (ID) WHERE DISPATCHED IS NULL",
+	},
+	"SQLite": {
+		"CREATE TABLE IF NOT EXISTS %[1]v (ID INTEGER PRIMARY KEY AUTOINCREMENT, AGGREGATE_ID TEXT NOT NULL, EVENT_TYPE TEXT NOT NULL, PAYLOAD TEXT NOT NULL, CREATED TIMESTAMP NOT NULL, DISPATCHED TIMESTAMP NULL)",
+		"CREATE INDEX IF NOT EXISTS %[2]v ON %[1]v (DISPATCHED, ID)",
+	},
+	"Oracle": {
+		"CREATE TABLE %[1]v (ID NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, AGGREGATE_ID VARCHAR2(255) NOT NULL, EVENT_TYPE VARCHAR2(255) NOT NULL, PAYLOAD CLOB NOT NULL, CREATED TIMESTAMP NOT NULL, DISPATCHED TIMESTAMP NULL)",
+		"CREATE INDEX %[2]v ON %[1]v (DISPATCHED, ID)",
+	},
+	"SQLServer": {
+		"CREATE TABLE %[1]v (ID BIGINT IDENTITY(1,1) PRIMARY KEY, AGGREGATE_ID NVARCHAR(255) NOT NULL, EVENT_TYPE NVARCHAR(255) NOT NULL, PAYLOAD NVARCHAR(MAX) NOT NULL, CREATED DATETIME2 NOT NULL, DISPATCHED DATETIME2 NULL)",
+		"CREATE INDEX %[2]v ON %[1]v (DISPATCHED, ID)",
+	},
+}
+
+var ddlMux sync.RWMutex
+
+// RegisterDDL registers product outbox table DDL templates, %[1]v is replaced with table name, %[2]v with index name
+func RegisterDDL(product string, templates ...string) {
+	ddlMux.Lock()
+	defer ddlMux.Unlock()
+	ddls[product] = templates
+}
+
+// DDL returns dialect specific statements creating outbox table
+func DDL(dialect *info.Dialect, table string) ([]string, error) {
+	ddlMux.RLock()
+	templates, ok := ddls[dialect.Name]
+	ddlMux.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported outbox product: %v", dialect.Name)
+	}
+	index := table
+	if pos := strings.LastIndex(index, "."); pos != -1 {
+		index = index[pos+1:]
+	}
+	index += "_PENDING"
+	var result = make([]string, len(templates))
+	for i, template := range templates {
+		result[i] = fmt.Sprintf(template, table, index)
+	}
+	return result, nil
+}
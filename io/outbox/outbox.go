@@ -0,0 +1,141 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Operation represents write operation producing outbox event
+type Operation string
+
+const (
+	//OpInsert represents insert operation
+	OpInsert = Operation("insert")
+	//OpUpdate represents update operation
+	OpUpdate = Operation("update")
+	//OpDelete represents delete operation
+	OpDelete = Operation("delete")
+)
+
+type (
+	// Event represents outbox event row
+	Event struct {
+		ID          int64
+		AggregateID string
+		Type        string
+		Payload     json.RawMessage
+		Created     time.Time
+	}
+
+	// EventMapper maps written record to outbox event, nil event is skipped
+	EventMapper func(ctx context.Context, table string, op Operation, record interface{}) (*Event, error)
+
+	// DB represents transaction or database used to append events
+	DB interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	}
+
+	// Outbox represents insert, update and delete services option appending events of written records
+	// to outbox table within service transaction
+	Outbox struct {
+		Table  string
+		Mapper EventMapper
+		Now    func() time.Time
+	}
+)
+
+// Append appends events of written records with service transaction, or database if dialect is not transactional, nil outbox is a no-op
+func (o *Outbox) Append(ctx context.Context, transaction *io.Transaction, db *sql.DB, dialect *info.Dialect, table string, op Operation, any interface{}) error {
+	if o == nil {
+		return nil
+	}
+	valueAt, count, err := io.Values(any)
+	if err != nil || count == 0 {
+		return err
+	}
+	mapper := o.Mapper
+	if mapper == nil {
+		if mapper, err = defaultMapper(valueAt(0)); err != nil {
+			return err
+		}
+	}
+	var events = make([]*Event, 0, count)
+	for i := 0; i < count; i++ {
+		event, err := mapper(ctx, table, op, valueAt(i))
+		if err != nil {
+			return fmt.Errorf("failed to map %v %v outbox event: %w", table, op, err)
+		}
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	var target DB = db
+	if transaction != nil {
+		target = transaction.Tx
+	}
+	return o.Write(ctx, target, dialect, events...)
+}
+
+// Write inserts events into outbox table, use it with caller transaction to append custom events
+func (o *Outbox) Write(ctx context.Context, db DB, dialect *info.Dialect, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	SQL := "INSERT INTO " + o.Table + "(AGGREGATE_ID, EVENT_TYPE, PAYLOAD, CREATED) VALUES (?, ?, ?, ?)"
+	if dialect != nil {
+		SQL = dialect.EnsurePlaceholders(SQL)
+	}
+	for _, event := range events {
+		if event.Created.IsZero() {
+			event.Created = o.now()
+		}
+		if _, err := db.ExecContext(ctx, SQL, event.AggregateID, event.Type, string(event.Payload), event.Created); err != nil {
+			return fmt.Errorf("failed to append %v event: %w", event.Type, err)
+		}
+	}
+	return nil
+}
+
+func (o *Outbox) now() time.Time {
+	if o.Now == nil {
+		return time.Now()
+	}
+	return o.Now()
+}
+
+// defaultMapper returns mapper creating "<table>.<operation>" event with JSON record payload and identity columns aggregate ID
+func defaultMapper(record interface{}) (EventMapper, error) {
+	columns, binder, err := io.StructColumnMapper(record, option.IdentityOnly(true))
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, table string, op Operation, record interface{}) (*Event, error) {
+		payload, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		var key = make([]interface{}, len(columns))
+		binder(record, key, 0, len(columns))
+		var aggregateID = make([]string, len(key))
+		for i, value := range key {
+			if rValue := reflect.ValueOf(value); rValue.Kind() == reflect.Ptr && !rValue.IsNil() {
+				value = rValue.Elem().Interface()
+			}
+			aggregateID[i] = fmt.Sprint(value)
+		}
+		return &Event{AggregateID: strings.Join(aggregateID, ":"), Type: table + "." + string(op), Payload: payload}, nil
+	}, nil
+}
+
+// New creates outbox appending events to supplied table, nil mapper uses "<table>.<operation>" events with JSON record payload
+func New(table string, mapper EventMapper) *Outbox {
+	return &Outbox{Table: table, Mapper: mapper, Now: time.Now}
+}
@@ -0,0 +1,95 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/outbox"
+	"github.com/viant/sqlx/io/update"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"path"
+	"testing"
+)
+
+type order struct {
+	ID     int    `sqlx:"id,primaryKey" json:"id"`
+	Status string `sqlx:"status" json:"status"`
+}
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "outbox.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	dialect, err := config.Dialect(ctx, db)
+	if !assert.Nil(t, err) {
+		return
+	}
+	DDL, err := outbox.DDL(dialect, "outbox")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range append(DDL, "CREATE TABLE orders (id INTEGER PRIMARY KEY, status TEXT)") {
+		_, err = db.Exec(SQL)
+		assert.Nil(t, err, SQL)
+	}
+	events := outbox.New("outbox", nil)
+
+	inserter, err := insert.New(ctx, db, "orders")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = inserter.Exec(ctx, []*order{{ID: 1, Status: "new"}, {ID: 2, Status: "new"}}, events)
+	assert.Nil(t, err, "insert with outbox")
+
+	updater, err := update.New(ctx, db, "orders")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = updater.Exec(ctx, []*order{{ID: 1, Status: "paid"}}, outbox.New("missing_outbox", nil))
+	assert.NotNil(t, err, "update with failing outbox")
+	var status string
+	assert.Nil(t, db.QueryRow("SELECT status FROM orders WHERE id = 1").Scan(&status))
+	assert.EqualValues(t, "new", status, "update rolled back with outbox")
+	_, err = updater.Exec(ctx, []*order{{ID: 1, Status: "paid"}, {ID: 3, Status: "paid"}}, events) //no event for not existing order 3
+	assert.Nil(t, err, "update with outbox")
+
+	var testCases = []struct {
+		description string
+		batchSize   int
+		handlerErr  error
+		expectCount int
+		expect      []string
+	}{
+		{description: "handler error keeps events pending", batchSize: 1, handlerErr: errors.New("publish failed"), expect: []string{"1:orders.insert"}},
+		{description: "first batch", batchSize: 2, expectCount: 2, expect: []string{"1:orders.insert", "2:orders.insert"}},
+		{description: "second batch", batchSize: 2, expectCount: 1, expect: []string{"1:orders.update"}},
+		{description: "no pending events", batchSize: 2},
+	}
+	var payload string
+	for _, testCase := range testCases {
+		var actual []string
+		poller := outbox.NewPoller(db, "outbox", func(ctx context.Context, events []*outbox.Event) error {
+			for _, event := range events {
+				actual = append(actual, event.AggregateID+":"+event.Type)
+				payload = string(event.Payload)
+			}
+			return testCase.handlerErr
+		}, outbox.WithBatchSize(testCase.batchSize))
+		count, err := poller.Poll(ctx)
+		if testCase.handlerErr != nil {
+			assert.ErrorIs(t, err, testCase.handlerErr, testCase.description)
+		} else {
+			assert.Nil(t, err, testCase.description)
+		}
+		assert.EqualValues(t, testCase.expectCount, count, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+	assert.EqualValues(t, `{"id":1,"status":"paid"}`, payload)
+}
@@ -0,0 +1,214 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/database"
+	"github.com/viant/sqlx/metadata/info"
+	"strings"
+	"time"
+)
+
+const (
+	//DefaultBatchSize represents default poller batch size
+	DefaultBatchSize = 100
+	//DefaultInterval represents default poller interval
+	DefaultInterval = time.Second
+)
+
+type (
+	// Handler publishes pending events, poller marks events dispatched only if handler returns no error
+	Handler func(ctx context.Context, events []*Event) error
+
+	// Poller reads pending outbox events with FOR UPDATE SKIP LOCKED where supported and marks them dispatched,
+	// without SKIP LOCKED support only single poller should run per outbox table
+	Poller struct {
+		db         *sql.DB
+		dialect    *info.Dialect
+		table      string
+		handler    Handler
+		batchSize  int
+		interval   time.Duration
+		skipLocked *bool
+		now        func() time.Time
+	}
+
+	// PollerOption represents poller option
+	PollerOption func(p *Poller)
+)
+
+// WithBatchSize sets max number of events read by single poll
+func WithBatchSize(batchSize int) PollerOption {
+	return func(p *Poller) {
+		p.batchSize = batchSize
+	}
+}
+
+// WithInterval sets interval between polls returning no events
+func WithInterval(interval time.Duration) PollerOption {
+	return func(p *Poller) {
+		p.interval = interval
+	}
+}
+
+// WithSkipLocked overrides dialect SKIP LOCKED support, by default it is disabled for detected MySQL older than 8.0.1
+func WithSkipLocked(skipLocked bool) PollerOption {
+	return func(p *Poller) {
+		p.skipLocked = &skipLocked
+	}
+}
+
+// WithDialect sets poller dialect
+func WithDialect(dialect *info.Dialect) PollerOption {
+	return func(p *Poller) {
+		p.dialect = dialect
+	}
+}
+
+// Poll reads one batch of pending events within transaction, calls handler and marks events dispatched, it returns number of dispatched events
+func (p *Poller) Poll(ctx context.Context) (int, error) {
+	dialect, err := p.ensureDialect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	count, err := p.poll(ctx, tx, dialect)
+	if err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return 0, fmt.Errorf("failed to rollback: %w, %v", err, rErr)
+		}
+		return 0, err
+	}
+	return count, tx.Commit()
+}
+
+func (p *Poller) poll(ctx context.Context, tx *sql.Tx, dialect *info.Dialect) (int, error) {
+	rows, err := tx.QueryContext(ctx, p.selectSQL(dialect))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %v events: %w", p.table, err)
+	}
+	var events []*Event
+	for rows.Next() {
+		event := &Event{}
+		var payload []byte
+		if err = rows.Scan(&event.ID, &event.AggregateID, &event.Type, &payload, &event.Created); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, err
+	}
+	if err = rows.Close(); err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+	if err = p.handler(ctx, events); err != nil {
+		return 0, err
+	}
+	var args = []interface{}{p.now()}
+	for _, event := range events {
+		args = append(args, event.ID)
+	}
+	SQL := "UPDATE " + p.table + " SET DISPATCHED = ? WHERE ID IN (" + strings.TrimSuffix(strings.Repeat("?,", len(events)), ",") + ")"
+	if _, err = tx.ExecContext(ctx, dialect.EnsurePlaceholders(SQL), args...); err != nil {
+		return 0, fmt.Errorf("failed to mark %v events dispatched: %w", p.table, err)
+	}
+	return len(events), nil
+}
+
+func (p *Poller) selectSQL(dialect *info.Dialect) string {
+	SQL := "SELECT ID, AGGREGATE_ID, EVENT_TYPE, PAYLOAD, CREATED FROM " + p.table + " WHERE DISPATCHED IS NULL ORDER BY ID"
+	switch dialect.Name {
+	case "Oracle":
+		SQL += fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", p.batchSize)
+	case "SQLServer":
+		SQL += fmt.Sprintf(" OFFSET 0 ROWS FETCH NEXT %d ROWS ONLY", p.batchSize)
+	default:
+		SQL += fmt.Sprintf(" LIMIT %d", p.batchSize)
+	}
+	skipLocked := dialect.SkipLocked
+	if p.skipLocked != nil {
+		skipLocked = *p.skipLocked
+	}
+	if skipLocked {
+		SQL += " FOR UPDATE SKIP LOCKED"
+	}
+	return SQL
+}
+
+// Run polls events until context is done, it polls again without waiting while batches are full
+func (p *Poller) Run(ctx context.Context) error {
+	for {
+		count, err := p.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if count == p.batchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// skipLockedSince represents minimum product versions supporting SKIP LOCKED, where dialect is shared with older versions
+var skipLockedSince = map[string]database.Product{
+	"MySQL":      {Major: 8, Minor: 0, Release: 1},
+	"PostgreSQL": {Major: 9, Minor: 5},
+}
+
+func (p *Poller) ensureDialect(ctx context.Context) (*info.Dialect, error) {
+	if p.dialect != nil {
+		return p.dialect, nil
+	}
+	product, err := metadata.New().DetectProduct(ctx, p.db)
+	if err != nil {
+		return nil, err
+	}
+	dialect, err := config.Dialect(ctx, p.db, product)
+	if err != nil {
+		return nil, err
+	}
+	if p.skipLocked == nil && dialect.SkipLocked && !canSkipLocked(product) {
+		skipLocked := false
+		p.skipLocked = &skipLocked
+	}
+	p.dialect = dialect
+	return dialect, nil
+}
+
+// canSkipLocked returns false if detected product version is older than the first version supporting SKIP LOCKED
+func canSkipLocked(product *database.Product) bool {
+	since, ok := skipLockedSince[product.Name]
+	if !ok || product.Major == 0 { //unknown version
+		return true
+	}
+	return !info.IsOlder(product, since.Major, since.Minor, since.Release)
+}
+
+// NewPoller creates outbox table poller
+func NewPoller(db *sql.DB, table string, handler Handler, options ...PollerOption) *Poller {
+	result := &Poller{db: db, table: table, handler: handler, batchSize: DefaultBatchSize, interval: DefaultInterval, now: time.Now}
+	for _, opt := range options {
+		opt(result)
+	}
+	return result
+}
@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/database"
+	"testing"
+)
+
+func TestCanSkipLocked(t *testing.T) {
+	var testCases = []struct {
+		description string
+		product     database.Product
+		expect      bool
+	}{
+		{description: "MySQL 5.7", product: database.Product{Name: "MySQL", Major: 5, Minor: 7, Release: 44}},
+		{description: "MySQL 8.0.0", product: database.Product{Name: "MySQL", Major: 8}},
+		{description: "MySQL 8.0.36", product: database.Product{Name: "MySQL", Major: 8, Release: 36}, expect: true},
+		{description: "PostgreSQL 9.4", product: database.Product{Name: "PostgreSQL", Major: 9, Minor: 4}},
+		{description: "PostgreSQL 16", product: database.Product{Name: "PostgreSQL", Major: 16}, expect: true},
+		{description: "unknown version", product: database.Product{Name: "MySQL"}, expect: true},
+		{description: "other product", product: database.Product{Name: "Oracle", Major: 11}, expect: true},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, canSkipLocked(&testCase.product), testCase.description)
+	}
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/viant/afs"
 	"github.com/viant/afs/option"
 	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/codec"
 	"github.com/viant/sqlx/io/read/cache/hash"
+	sio "io"
 	"strings"
 	"sync"
 	"time"
@@ -38,6 +40,7 @@ type (
 		canWrite  map[string]bool
 		stream    *option.Stream
 		recorder  cache.Recorder
+		codecs    codec.Chain
 	}
 )
 
@@ -49,13 +52,18 @@ func (c *Cache) Rollback(ctx context.Context, entry *cache.Entry) error {
 	return c.Delete(ctx, entry)
 }
 
-// NewCache creates new cache.
+// NewCache creates new cache, codec.Codec options (i.e. compression, encryption) are applied in supplied order when writing entries
 func NewCache(URL string, ttl time.Duration, signature string, stream *option.Stream, options ...interface{}) (*Cache, error) {
 	var recorder cache.Recorder
+	var codecs codec.Chain
 	for _, anOption := range options {
 		switch actual := anOption.(type) {
 		case cache.Recorder:
 			recorder = actual
+		case codec.Chain:
+			codecs = append(codecs, actual...)
+		case codec.Codec:
+			codecs = append(codecs, actual)
 		}
 	}
 
@@ -71,6 +79,7 @@ func NewCache(URL string, ttl time.Duration, signature string, stream *option.St
 		canWrite:  map[string]bool{},
 		stream:    stream,
 		recorder:  recorder,
+		codecs:    codecs,
 	}
 
 	return cache, nil
@@ -192,12 +201,18 @@ func (c *Cache) readData(ctx context.Context, entry *cache.Entry) (int, error) {
 		return ErrorStatus, nil
 	}
 
-	reader := bufio.NewReader(afsReader)
+	if len(c.codecs) == 0 {
+		entry.SetReader(bufio.NewReader(afsReader), afsReader)
+		return ExistsStatus, nil
+	}
+
+	decoder, err := c.codecs.Decoder(afsReader)
 	if err != nil {
-		return ErrorStatus, err
+		_ = afsReader.Close()
+		return NotExistStatus, c.afs.Delete(ctx, entry.Meta.URL)
 	}
 
-	entry.SetReader(reader, afsReader)
+	entry.SetReader(bufio.NewReader(decoder), closers{decoder, afsReader})
 	return ExistsStatus, nil
 }
 
@@ -226,8 +241,20 @@ func (c *Cache) writeMeta(ctx context.Context, m *cache.Entry) error {
 		return fmt.Errorf("invalid writer location: %v", m.Meta.URL)
 	}
 
-	bufioWriter := bufio.NewWriterSize(writer, 2048)
-	m.WriteCloser = cache.NewWriteCloser(cache.NewLineWriter(bufioWriter), writer)
+	var dest sio.Writer = writer
+	var closer sio.Closer = writer
+	if len(c.codecs) > 0 {
+		encoder, err := c.codecs.Encoder(writer)
+		if err != nil {
+			_ = writer.Close()
+			return err
+		}
+		dest = encoder
+		closer = closers{encoder, writer}
+	}
+
+	bufioWriter := bufio.NewWriterSize(dest, 2048)
+	m.WriteCloser = cache.NewWriteCloser(cache.NewLineWriter(bufioWriter), closer)
 
 	m.Meta.ExpiryTimeMs = int(cache.Now().Add(c.ttl).UnixMilli())
 	data, err := json.Marshal(m.Meta)
@@ -0,0 +1,82 @@
+package afs
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/sqlx/io/read/cache/codec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCache_Codecs(t *testing.T) {
+	aesCodec, err := codec.NewAESGCM([]byte("0123456789abcdef"))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	testCases := []struct {
+		description string
+		URL         string
+		codecs      []interface{}
+		encrypted   bool
+	}{
+		{description: "plain", URL: "mem://localhost/cache/plain"},
+		{description: "gzip", URL: "mem://localhost/cache/gzip", codecs: []interface{}{codec.NewGzip()}},
+		{description: "zstd and aes-gcm", URL: "mem://localhost/cache/zstd_aes", codecs: []interface{}{codec.NewZstd(), aesCodec}, encrypted: true},
+	}
+
+	ctx := context.Background()
+	fs := afs.New()
+	for _, testCase := range testCases {
+		aCache, err := NewCache(testCase.URL, time.Minute, "sig", nil, testCase.codecs...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		SQL := "SELECT ID, NAME FROM users WHERE ID > ?"
+		args := []interface{}{1}
+
+		entry, err := aCache.Get(ctx, SQL, args)
+		if !assert.Nil(t, err, testCase.description) || !assert.NotNil(t, entry, testCase.description) {
+			continue
+		}
+		assert.False(t, entry.Has(), testCase.description)
+		id, name := 2, "john@example.com"
+		assert.Nil(t, aCache.AddValues(ctx, entry, []interface{}{&id, &name}), testCase.description)
+		assert.Nil(t, aCache.Close(ctx, entry), testCase.description)
+
+		objects, err := fs.List(ctx, testCase.URL)
+		assert.Nil(t, err, testCase.description)
+		for _, object := range objects {
+			if object.IsDir() {
+				continue
+			}
+			data, err := fs.DownloadWithURL(ctx, object.URL())
+			assert.Nil(t, err, testCase.description)
+			if testCase.encrypted {
+				assert.False(t, strings.Contains(string(data), "john@example.com"), testCase.description)
+			}
+		}
+
+		entry, err = aCache.Get(ctx, SQL, args)
+		if !assert.Nil(t, err, testCase.description) || !assert.NotNil(t, entry, testCase.description) {
+			continue
+		}
+		assert.True(t, entry.Has(), testCase.description)
+		assert.True(t, entry.Next(), testCase.description)
+		assert.EqualValues(t, `[2,"john@example.com"]`, string(entry.Data), testCase.description)
+		assert.Nil(t, aCache.Close(ctx, entry), testCase.description)
+	}
+
+	mismatched, err := NewCache("mem://localhost/cache/gzip", time.Minute, "sig", nil, codec.NewZstd())
+	if !assert.Nil(t, err) {
+		return
+	}
+	entry, err := mismatched.Get(ctx, "SELECT ID, NAME FROM users WHERE ID > ?", []interface{}{1})
+	assert.Nil(t, err)
+	if assert.NotNil(t, entry) {
+		exists, _ := fs.Exists(ctx, entry.Meta.URL)
+		assert.False(t, exists, "entry encoded with different codec should be discarded")
+	}
+}
@@ -0,0 +1,16 @@
+package afs
+
+import "io"
+
+// closers closes all closers in order, i.e. codec encoder first then storage writer
+type closers []io.Closer
+
+func (c closers) Close() error {
+	var err error
+	for _, closer := range c {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,205 @@
+package codec
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	defaultChunkSize = 64 * 1024
+	maxChunkSize     = 16 * 1024 * 1024
+	lastChunkFlag    = uint32(1) << 31
+	chunkHeaderSize  = 4
+)
+
+// AESGCM represents AES-GCM encryption codec, data is sealed in chunks, each chunk uses distinct nonce
+// derived from random per stream prefix and chunk sequence, last chunk is flagged to detect truncation
+type AESGCM struct {
+	aead      cipher.AEAD
+	ChunkSize int
+}
+
+func (a *AESGCM) Encoder(writer io.Writer) (io.WriteCloser, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(nonce); err != nil {
+		return nil, err
+	}
+	chunkSize := a.ChunkSize
+	if chunkSize <= 0 || chunkSize > maxChunkSize {
+		chunkSize = defaultChunkSize
+	}
+	return &aesWriter{
+		aead:   a.aead,
+		writer: writer,
+		nonce:  nonce,
+		buffer: make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (a *AESGCM) Decoder(reader io.Reader) (io.ReadCloser, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := io.ReadFull(reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to read nonce: %w", err)
+	}
+	return &aesReader{
+		aead:   a.aead,
+		reader: reader,
+		nonce:  nonce,
+	}, nil
+}
+
+// NewAESGCM creates AES-GCM codec, key has to be 16, 24 or 32 bytes long (AES-128, AES-192, AES-256)
+func NewAESGCM(key []byte) (*AESGCM, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESGCM{aead: aead, ChunkSize: defaultChunkSize}, nil
+}
+
+type (
+	aesWriter struct {
+		aead     cipher.AEAD
+		writer   io.Writer
+		nonce    []byte
+		sequence uint64
+		buffer   []byte
+		sealed   []byte
+		closed   bool
+	}
+
+	aesReader struct {
+		aead     cipher.AEAD
+		reader   io.Reader
+		nonce    []byte
+		sequence uint64
+		sealed   []byte
+		plain    []byte
+		offset   int
+		last     bool
+	}
+)
+
+func (w *aesWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("write on closed encoder")
+	}
+	written := 0
+	for len(p) > 0 {
+		n := copy(w.buffer[len(w.buffer):cap(w.buffer)], p)
+		w.buffer = w.buffer[:len(w.buffer)+n]
+		written += n
+		p = p[n:]
+		if len(w.buffer) == cap(w.buffer) {
+			if err := w.seal(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (w *aesWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.seal(true)
+}
+
+func (w *aesWriter) seal(last bool) error {
+	header := uint32(0)
+	if last {
+		header = lastChunkFlag
+	}
+	nonce := chunkNonce(w.nonce, w.sequence)
+	w.sequence++
+
+	w.sealed = append(w.sealed[:0], 0, 0, 0, 0)
+	w.sealed = w.aead.Seal(w.sealed, nonce, w.buffer, chunkAdditionalData(last))
+	binary.BigEndian.PutUint32(w.sealed, header|uint32(len(w.sealed)-chunkHeaderSize))
+	w.buffer = w.buffer[:0]
+	_, err := w.writer.Write(w.sealed)
+	return err
+}
+
+func (r *aesReader) Read(p []byte) (int, error) {
+	for r.offset >= len(r.plain) {
+		if r.last {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain[r.offset:])
+	r.offset += n
+	return n, nil
+}
+
+func (r *aesReader) open() error {
+	header := make([]byte, chunkHeaderSize)
+	if _, err := io.ReadFull(r.reader, header); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(header)
+	last := size&lastChunkFlag != 0
+	size &^= lastChunkFlag
+	if size > maxChunkSize+uint32(r.aead.Overhead()) {
+		return fmt.Errorf("invalid chunk size: %v", size)
+	}
+	if cap(r.sealed) < int(size) {
+		r.sealed = make([]byte, size)
+	}
+	r.sealed = r.sealed[:size]
+	if _, err := io.ReadFull(r.reader, r.sealed); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	nonce := chunkNonce(r.nonce, r.sequence)
+	r.sequence++
+	plain, err := r.aead.Open(r.plain[:0], nonce, r.sealed, chunkAdditionalData(last))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt chunk %v: %w", r.sequence-1, err)
+	}
+	r.plain = plain
+	r.offset = 0
+	r.last = last
+	return nil
+}
+
+func (r *aesReader) Close() error {
+	return nil
+}
+
+func chunkNonce(prefix []byte, sequence uint64) []byte {
+	nonce := make([]byte, len(prefix))
+	copy(nonce, prefix)
+	offset := len(nonce) - 8
+	binary.BigEndian.PutUint64(nonce[offset:], binary.BigEndian.Uint64(nonce[offset:])^sequence)
+	return nonce
+}
+
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
@@ -0,0 +1,77 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+)
+
+// Codec represents cache entry stream codec (compression, encryption)
+type Codec interface {
+	//Encoder wraps storage writer, data written to encoder gets encoded
+	Encoder(writer io.Writer) (io.WriteCloser, error)
+	//Decoder wraps storage reader, data read from decoder gets decoded
+	Decoder(reader io.Reader) (io.ReadCloser, error)
+}
+
+// Chain represents codecs applied in order when encoding, and in reverse order when decoding
+type Chain []Codec
+
+// Encoder creates chained encoder, closing it closes all encoders but not the underlying writer
+func (c Chain) Encoder(writer io.Writer) (io.WriteCloser, error) {
+	result := &chainWriter{Writer: writer}
+	for i := len(c) - 1; i >= 0; i-- {
+		encoder, err := c[i].Encoder(result.Writer)
+		if err != nil {
+			_ = result.Close()
+			return nil, fmt.Errorf("failed to create %T encoder: %w", c[i], err)
+		}
+		result.closers = append(result.closers, encoder)
+		result.Writer = encoder
+	}
+	return result, nil
+}
+
+// Decoder creates chained decoder, closing it closes all decoders but not the underlying reader
+func (c Chain) Decoder(reader io.Reader) (io.ReadCloser, error) {
+	result := &chainReader{Reader: reader}
+	for i := len(c) - 1; i >= 0; i-- {
+		decoder, err := c[i].Decoder(result.Reader)
+		if err != nil {
+			_ = result.Close()
+			return nil, fmt.Errorf("failed to create %T decoder: %w", c[i], err)
+		}
+		result.closers = append(result.closers, decoder)
+		result.Reader = decoder
+	}
+	return result, nil
+}
+
+type (
+	chainWriter struct {
+		io.Writer
+		closers []io.Closer
+	}
+
+	chainReader struct {
+		io.Reader
+		closers []io.Closer
+	}
+)
+
+func (w *chainWriter) Close() error {
+	return closeAll(w.closers)
+}
+
+func (r *chainReader) Close() error {
+	return closeAll(r.closers)
+}
+
+func closeAll(closers []io.Closer) error {
+	var err error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if closeErr := closers[i].Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package codec
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	aesCodec, err := NewAESGCM(key)
+	if !assert.Nil(t, err) {
+		return
+	}
+	smallChunks, _ := NewAESGCM(key)
+	smallChunks.ChunkSize = 7
+
+	payload := `{"SQL":"SELECT * FROM users","Args":"[]"}` + "\n" + strings.Repeat(`[1,"abc",true]`+"\n", 1000)
+	testCases := []struct {
+		description string
+		chain       Chain
+		encrypted   bool
+	}{
+		{description: "no codecs", chain: Chain{}},
+		{description: "gzip", chain: Chain{NewGzip()}},
+		{description: "zstd", chain: Chain{NewZstd()}},
+		{description: "aes-gcm", chain: Chain{aesCodec}, encrypted: true},
+		{description: "aes-gcm small chunks", chain: Chain{smallChunks}, encrypted: true},
+		{description: "gzip and aes-gcm", chain: Chain{NewGzip(), aesCodec}, encrypted: true},
+		{description: "zstd and aes-gcm", chain: Chain{NewZstd(), aesCodec}, encrypted: true},
+	}
+
+	for _, testCase := range testCases {
+		buffer := &bytes.Buffer{}
+		encoder, err := testCase.chain.Encoder(buffer)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, err = encoder.Write([]byte(payload))
+		assert.Nil(t, err, testCase.description)
+		assert.Nil(t, encoder.Close(), testCase.description)
+		if testCase.encrypted {
+			assert.False(t, bytes.Contains(buffer.Bytes(), []byte("SELECT")), testCase.description)
+		}
+
+		decoder, err := testCase.chain.Decoder(bytes.NewReader(buffer.Bytes()))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual, err := io.ReadAll(decoder)
+		assert.Nil(t, err, testCase.description)
+		assert.Nil(t, decoder.Close(), testCase.description)
+		assert.EqualValues(t, payload, string(actual), testCase.description)
+	}
+}
+
+func TestAESGCM_Decoder(t *testing.T) {
+	aesCodec, _ := NewAESGCM([]byte("0123456789abcdef"))
+	aesCodec.ChunkSize = 16
+	otherKey, _ := NewAESGCM([]byte("fedcba9876543210"))
+
+	encode := func() []byte {
+		buffer := &bytes.Buffer{}
+		encoder, _ := aesCodec.Encoder(buffer)
+		_, _ = encoder.Write([]byte(strings.Repeat("sensitive data ", 10)))
+		_ = encoder.Close()
+		return buffer.Bytes()
+	}
+
+	testCases := []struct {
+		description string
+		codec       Codec
+		modify      func(data []byte) []byte
+	}{
+		{description: "tampered", codec: aesCodec, modify: func(data []byte) []byte {
+			data[len(data)/2] ^= 0xFF
+			return data
+		}},
+		{description: "truncated", codec: aesCodec, modify: func(data []byte) []byte {
+			return data[:len(data)-(16+aesCodec.aead.Overhead()+chunkHeaderSize)]
+		}},
+		{description: "wrong key", codec: otherKey, modify: func(data []byte) []byte {
+			return data
+		}},
+	}
+
+	for _, testCase := range testCases {
+		decoder, err := testCase.codec.Decoder(bytes.NewReader(testCase.modify(encode())))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, err = io.ReadAll(decoder)
+		assert.NotNil(t, err, testCase.description)
+	}
+}
@@ -0,0 +1,24 @@
+package codec
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// Gzip represents gzip compression codec
+type Gzip struct {
+	Level int
+}
+
+func (g *Gzip) Encoder(writer io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(writer, g.Level)
+}
+
+func (g *Gzip) Decoder(reader io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(reader)
+}
+
+// NewGzip creates gzip codec
+func NewGzip() *Gzip {
+	return &Gzip{Level: gzip.DefaultCompression}
+}
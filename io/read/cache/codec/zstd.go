@@ -0,0 +1,28 @@
+package codec
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"io"
+)
+
+// Zstd represents zstd compression codec
+type Zstd struct {
+	Level zstd.EncoderLevel
+}
+
+func (z *Zstd) Encoder(writer io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(writer, zstd.WithEncoderLevel(z.Level))
+}
+
+func (z *Zstd) Decoder(reader io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// NewZstd creates zstd codec
+func NewZstd() *Zstd {
+	return &Zstd{Level: zstd.SpeedDefault}
+}
@@ -0,0 +1,183 @@
+package warmup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron represents parsed 5 field cron expression (minute hour day-of-month month day-of-week)
+type Cron struct {
+	minute     uint64
+	hour       uint64
+	dom        uint64
+	month      uint64
+	dow        uint64
+	anyDom     bool
+	anyDow     bool
+	every      time.Duration
+	expression string
+}
+
+type cronRange struct {
+	min, max int
+}
+
+var (
+	minuteRange = cronRange{0, 59}
+	hourRange   = cronRange{0, 23}
+	domRange    = cronRange{1, 31}
+	monthRange  = cronRange{1, 12}
+	dowRange    = cronRange{0, 7} //0 and 7 both represents Sunday
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// ParseCron parses cron expression, supported are lists, ranges, steps, descriptors (@daily, @hourly, ...) and @every <duration>
+func ParseCron(expression string) (*Cron, error) {
+	expr := strings.TrimSpace(expression)
+	if strings.HasPrefix(expr, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(expr[len("@every "):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression: %v, %w", expression, err)
+		}
+		if every <= 0 {
+			return nil, fmt.Errorf("invalid cron expression: %v, duration has to be positive", expression)
+		}
+		return &Cron{every: every, expression: expression}, nil
+	}
+
+	if descriptor, ok := cronDescriptors[expr]; ok {
+		expr = descriptor
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid cron expression: %v, expected 5 fields but had %v", expression, len(parts))
+	}
+
+	result := &Cron{expression: expression}
+	var err error
+	if result.minute, err = parseCronField(parts[0], minuteRange); err != nil {
+		return nil, fmt.Errorf("invalid cron minute: %w", err)
+	}
+	if result.hour, err = parseCronField(parts[1], hourRange); err != nil {
+		return nil, fmt.Errorf("invalid cron hour: %w", err)
+	}
+	if result.dom, err = parseCronField(parts[2], domRange); err != nil {
+		return nil, fmt.Errorf("invalid cron day of month: %w", err)
+	}
+	if result.month, err = parseCronField(parts[3], monthRange); err != nil {
+		return nil, fmt.Errorf("invalid cron month: %w", err)
+	}
+	if result.dow, err = parseCronField(parts[4], dowRange); err != nil {
+		return nil, fmt.Errorf("invalid cron day of week: %w", err)
+	}
+	if result.dow&(1<<7) != 0 {
+		result.dow |= 1
+	}
+
+	result.anyDom = parts[2] == "*" || parts[2] == "?"
+	result.anyDow = parts[4] == "*" || parts[4] == "?"
+	return result, nil
+}
+
+func parseCronField(field string, aRange cronRange) (uint64, error) {
+	var result uint64
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if index := strings.Index(item, "/"); index != -1 {
+			var err error
+			if step, err = strconv.Atoi(item[index+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step: %v", item)
+			}
+			item = item[:index]
+		}
+
+		from, to := aRange.min, aRange.max
+		switch {
+		case item == "*" || item == "?":
+		case strings.Contains(item, "-"):
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid range: %v", item)
+			}
+			if to, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid range: %v", item)
+			}
+		default:
+			value, err := strconv.Atoi(item)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value: %v", item)
+			}
+			from = value
+			to = value
+			if step > 1 {
+				to = aRange.max
+			}
+		}
+
+		if from < aRange.min || to > aRange.max || from > to {
+			return 0, fmt.Errorf("value out of range [%v-%v]: %v", aRange.min, aRange.max, field)
+		}
+
+		for i := from; i <= to; i += step {
+			result |= 1 << uint(i)
+		}
+	}
+	return result, nil
+}
+
+// Next returns next activation time after supplied time
+func (c *Cron) Next(after time.Time) time.Time {
+	if c.every > 0 {
+		return after.Add(c.every)
+	}
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.anyDom || c.anyDow {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// String returns cron expression
+func (c *Cron) String() string {
+	return c.expression
+}
@@ -0,0 +1,63 @@
+package warmup
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/io/read/cache"
+	"time"
+)
+
+// Query represents registered cache warm-up query
+type Query struct {
+	Name     string
+	Query    *cache.ParmetrizedQuery //SQL, Args and By (index column, empty for the whole query result)
+	Interval time.Duration           //refresh interval, takes precedence over Cron
+	Cron     string                  //refresh cron expression, i.e. "*/15 * * * *" or "@every 10m"
+	cron     *Cron
+}
+
+// NewQuery creates a warm-up query
+func NewQuery(name string, SQL string, by string, args ...interface{}) *Query {
+	return &Query{
+		Name: name,
+		Query: &cache.ParmetrizedQuery{
+			By:   by,
+			SQL:  SQL,
+			Args: args,
+		},
+	}
+}
+
+// Init initialises query
+func (q *Query) Init() error {
+	if q.Query == nil || q.Query.SQL == "" {
+		return fmt.Errorf("warmup query %v: SQL was empty", q.Name)
+	}
+	if q.Name == "" {
+		q.Name = q.Query.SQL
+		if q.Query.By != "" {
+			q.Name += "/" + q.Query.By
+		}
+	}
+	q.Query.Init()
+	if q.Interval > 0 || q.Cron == "" {
+		return nil
+	}
+	var err error
+	if q.cron, err = ParseCron(q.Cron); err != nil {
+		return fmt.Errorf("warmup query %v: %w", q.Name, err)
+	}
+	return nil
+}
+
+func (q *Query) next(now time.Time, defaultInterval time.Duration) (time.Time, bool) {
+	switch {
+	case q.Interval > 0:
+		return now.Add(q.Interval), true
+	case q.cron != nil:
+		next := q.cron.Next(now)
+		return next, !next.IsZero()
+	case defaultInterval > 0:
+		return now.Add(defaultInterval), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,259 @@
+package warmup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io/read/cache"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConcurrency   = 4
+	defaultRefreshLeadPc = 10
+)
+
+type (
+	// Service represents cache warm-up scheduler, it pre-populates cache with registered queries and refreshes them before cache entries expire
+	Service struct {
+		cache   cache.Cache
+		db      *sql.DB
+		config  *Config
+		queries []*job
+		mux     sync.RWMutex
+		limiter chan bool
+		ctx     context.Context
+		cancel  context.CancelFunc
+		wg      sync.WaitGroup
+		nowFn   func() time.Time
+	}
+
+	// Config represents warm-up scheduler config
+	Config struct {
+		Concurrency   int //max number of queries populating cache at the same time
+		JitterMs      int //max random delay added to each scheduled refresh
+		TTLMs         int //cache entries time to live, used to derive default refresh interval
+		RefreshLeadMs int //how long before TTL expiry queries get refreshed, defaults to 10% of TTL
+		TimeoutMs     int //single query warm-up timeout
+	}
+
+	job struct {
+		query  *Query
+		status *status
+		run    sync.Mutex
+	}
+)
+
+// Register registers warm-up queries, queries registered after Start get scheduled immediately
+func (s *Service) Register(queries ...*Query) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, query := range queries {
+		if err := query.Init(); err != nil {
+			return err
+		}
+		for _, candidate := range s.queries {
+			if candidate.query.Name == query.Name {
+				return fmt.Errorf("warmup query %v was already registered", query.Name)
+			}
+		}
+
+		aJob := &job{query: query, status: &status{Status: Status{Name: query.Name, State: StatusPending}}}
+		s.queries = append(s.queries, aJob)
+		if s.ctx != nil {
+			s.schedule(aJob)
+		}
+	}
+	return nil
+}
+
+// WarmUp populates cache with all registered queries and waits for completion
+func (s *Service) WarmUp(ctx context.Context) error {
+	s.mux.RLock()
+	jobs := make([]*job, len(s.queries))
+	copy(jobs, s.queries)
+	s.mux.RUnlock()
+
+	wg := sync.WaitGroup{}
+	errs := make([]error, len(jobs))
+	for i := range jobs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.execute(ctx, jobs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start starts background refresh, queries that have not been warmed up yet run immediately
+func (s *Service) Start(ctx context.Context) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.ctx != nil {
+		return fmt.Errorf("warmup service was already started")
+	}
+
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	for _, aJob := range s.queries {
+		s.schedule(aJob)
+	}
+	return nil
+}
+
+// Stop stops background refresh and waits for running queries
+func (s *Service) Stop() {
+	s.mux.Lock()
+	cancel := s.cancel
+	s.ctx = nil
+	s.cancel = nil
+	s.mux.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+}
+
+// Status returns warm-up query status
+func (s *Service) Status(name string) (Status, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	for _, aJob := range s.queries {
+		if aJob.query.Name == name {
+			return aJob.status.snapshot(), true
+		}
+	}
+	return Status{}, false
+}
+
+// Statuses returns all warm-up queries status sorted by name
+func (s *Service) Statuses() []Status {
+	s.mux.RLock()
+	result := make([]Status, 0, len(s.queries))
+	for _, aJob := range s.queries {
+		result = append(result, aJob.status.snapshot())
+	}
+	s.mux.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func (s *Service) schedule(aJob *job) {
+	ctx := s.ctx
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.watch(ctx, aJob)
+	}()
+}
+
+func (s *Service) watch(ctx context.Context, aJob *job) {
+	if aJob.status.snapshot().Runs == 0 {
+		_ = s.execute(ctx, aJob)
+	}
+
+	for {
+		next, ok := aJob.query.next(s.nowFn(), s.refreshInterval())
+		if !ok {
+			return
+		}
+
+		next = next.Add(s.jitter())
+		aJob.status.scheduled(next)
+		timer := time.NewTimer(next.Sub(s.nowFn()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		_ = s.execute(ctx, aJob)
+	}
+}
+
+func (s *Service) execute(ctx context.Context, aJob *job) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.limiter <- true:
+	}
+	defer func() { <-s.limiter }()
+
+	aJob.run.Lock()
+	defer aJob.run.Unlock()
+
+	if s.config.TimeoutMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(s.config.TimeoutMs)*time.Millisecond)
+		defer cancel()
+	}
+
+	query := aJob.query.Query
+	aJob.status.start(s.nowFn())
+	records, err := s.cache.IndexBy(ctx, s.db, query.By, query.SQL, query.Args)
+	if err != nil {
+		err = fmt.Errorf("failed to warm up %v: %w", aJob.query.Name, err)
+	}
+	aJob.status.end(s.nowFn(), records, err)
+	return err
+}
+
+func (s *Service) refreshInterval() time.Duration {
+	if s.config.TTLMs <= 0 {
+		return 0
+	}
+	lead := s.config.RefreshLeadMs
+	if lead <= 0 {
+		lead = s.config.TTLMs * defaultRefreshLeadPc / 100
+	}
+	interval := s.config.TTLMs - lead - s.config.JitterMs
+	if interval <= 0 {
+		interval = s.config.TTLMs / 2
+	}
+	return time.Duration(interval) * time.Millisecond
+}
+
+func (s *Service) jitter() time.Duration {
+	if s.config.JitterMs <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.config.JitterMs))) * time.Millisecond
+}
+
+// New creates cache warm-up service
+func New(aCache cache.Cache, db *sql.DB, config *Config) (*Service, error) {
+	if aCache == nil {
+		return nil, fmt.Errorf("warmup cache was nil")
+	}
+	if db == nil {
+		return nil, fmt.Errorf("warmup db was nil")
+	}
+	if config == nil {
+		config = &Config{}
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
+
+	return &Service{
+		cache:   aCache,
+		db:      db,
+		config:  config,
+		limiter: make(chan bool, config.Concurrency),
+		nowFn:   time.Now,
+	}, nil
+}
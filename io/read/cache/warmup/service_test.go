@@ -0,0 +1,169 @@
+package warmup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read/cache"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testCache struct {
+	cache.Cache
+	mux     sync.Mutex
+	indexed map[string]int
+	active  int
+	maxSeen int
+	err     error
+}
+
+func (c *testCache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, args []interface{}) (int, error) {
+	c.mux.Lock()
+	c.active++
+	if c.active > c.maxSeen {
+		c.maxSeen = c.active
+	}
+	c.indexed[SQL+"/"+column]++
+	c.mux.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mux.Lock()
+	c.active--
+	c.mux.Unlock()
+	return 3, c.err
+}
+
+func TestService_WarmUp(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	testCases := []struct {
+		description string
+		queries     []*Query
+		config      *Config
+		err         error
+		expected    map[string]int
+		maxActive   int
+	}{
+		{
+			description: "index by column",
+			queries: []*Query{
+				NewQuery("users", "SELECT * FROM users", "ID"),
+				NewQuery("", "SELECT * FROM events WHERE type = ?", "USER_ID", "click"),
+			},
+			expected: map[string]int{"SELECT * FROM users/ID": 1, "SELECT * FROM events WHERE type = ?/USER_ID": 1},
+		},
+		{
+			description: "concurrency limit",
+			queries: []*Query{
+				NewQuery("q1", "SELECT 1", ""),
+				NewQuery("q2", "SELECT 2", ""),
+				NewQuery("q3", "SELECT 3", ""),
+				NewQuery("q4", "SELECT 4", ""),
+			},
+			config:    &Config{Concurrency: 1},
+			expected:  map[string]int{"SELECT 1/": 1, "SELECT 2/": 1, "SELECT 3/": 1, "SELECT 4/": 1},
+			maxActive: 1,
+		},
+		{
+			description: "failure status",
+			queries:     []*Query{NewQuery("q1", "SELECT 1", "")},
+			err:         fmt.Errorf("connection refused"),
+			expected:    map[string]int{"SELECT 1/": 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		aCache := &testCache{indexed: map[string]int{}, err: testCase.err}
+		srv, err := New(aCache, db, testCase.config)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if !assert.Nil(t, srv.Register(testCase.queries...), testCase.description) {
+			continue
+		}
+
+		err = srv.WarmUp(context.Background())
+		assert.EqualValues(t, testCase.expected, aCache.indexed, testCase.description)
+		if testCase.maxActive > 0 {
+			assert.EqualValues(t, testCase.maxActive, aCache.maxSeen, testCase.description)
+		}
+
+		for _, status := range srv.Statuses() {
+			assert.EqualValues(t, 1, status.Runs, testCase.description)
+			if testCase.err != nil {
+				assert.NotNil(t, err, testCase.description)
+				assert.EqualValues(t, StatusFailed, status.State, testCase.description)
+				assert.EqualValues(t, 1, status.Failures, testCase.description)
+				continue
+			}
+			assert.Nil(t, err, testCase.description)
+			assert.EqualValues(t, StatusDone, status.State, testCase.description)
+			assert.EqualValues(t, 3, status.Records, testCase.description)
+		}
+	}
+}
+
+func TestService_Start(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	aCache := &testCache{indexed: map[string]int{}}
+	srv, _ := New(aCache, db, &Config{JitterMs: 5})
+	query := NewQuery("q1", "SELECT 1", "")
+	query.Interval = 20 * time.Millisecond
+	assert.Nil(t, srv.Register(query))
+	assert.Nil(t, srv.Start(context.Background()))
+	time.Sleep(120 * time.Millisecond)
+	srv.Stop()
+
+	status, ok := srv.Status("q1")
+	assert.True(t, ok)
+	assert.True(t, status.Runs >= 3, status.Runs)
+	assert.NotNil(t, status.NextRun)
+	assert.NotNil(t, srv.Register(&Query{Name: "q1", Query: &cache.ParmetrizedQuery{SQL: "SELECT 1"}}))
+}
+
+func TestParseCron(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 7, 30, 0, time.UTC) //Monday
+	testCases := []struct {
+		description string
+		expression  string
+		expected    time.Time
+		hasError    bool
+	}{
+		{description: "every minute", expression: "* * * * *", expected: time.Date(2024, 1, 15, 10, 8, 0, 0, time.UTC)},
+		{description: "step", expression: "*/15 * * * *", expected: time.Date(2024, 1, 15, 10, 15, 0, 0, time.UTC)},
+		{description: "list and range", expression: "0 8-9,12 * * *", expected: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{description: "day of week", expression: "30 6 * * 3", expected: time.Date(2024, 1, 17, 6, 30, 0, 0, time.UTC)},
+		{description: "sunday as 7", expression: "0 0 * * 7", expected: time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{description: "month", expression: "0 0 1 3 *", expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{description: "descriptor", expression: "@daily", expected: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{description: "every", expression: "@every 90s", expected: time.Date(2024, 1, 15, 10, 9, 0, 0, time.UTC)},
+		{description: "invalid fields", expression: "* * *", hasError: true},
+		{description: "out of range", expression: "61 * * * *", hasError: true},
+	}
+
+	for _, testCase := range testCases {
+		cron, err := ParseCron(testCase.expression)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expected, cron.Next(base), testCase.description)
+	}
+}
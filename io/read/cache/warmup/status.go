@@ -0,0 +1,70 @@
+package warmup
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+type (
+	// Status represents warm-up query status
+	Status struct {
+		Name       string
+		State      string
+		Runs       int
+		Failures   int
+		Records    int
+		LastStart  time.Time
+		LastEnd    time.Time
+		LastError  string     `json:",omitempty"`
+		NextRun    *time.Time `json:",omitempty"`
+		LastTimeMs int
+	}
+
+	status struct {
+		mux sync.RWMutex
+		Status
+	}
+)
+
+func (s *status) snapshot() Status {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.Status
+}
+
+func (s *status) start(now time.Time) {
+	s.mux.Lock()
+	s.State = StatusRunning
+	s.LastStart = now
+	s.mux.Unlock()
+}
+
+func (s *status) end(now time.Time, records int, err error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.Runs++
+	s.LastEnd = now
+	s.LastTimeMs = int(now.Sub(s.LastStart).Milliseconds())
+	s.Records = records
+	if err != nil {
+		s.Failures++
+		s.State = StatusFailed
+		s.LastError = err.Error()
+		return
+	}
+	s.State = StatusDone
+	s.LastError = ""
+}
+
+func (s *status) scheduled(next time.Time) {
+	s.mux.Lock()
+	s.NextRun = &next
+	s.mux.Unlock()
+}
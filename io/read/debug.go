@@ -1,7 +1,25 @@
 package read
 
+import (
+	"github.com/viant/sqlx/io/hook"
+	"os"
+)
+
 var showSQL bool
 
+// ShowSQL prints prepared SQL to stdout when no hooks were supplied with options
+//
+// Deprecated: use WithHooks(hook.NewPrinter(os.Stdout)) or hook.NewSlog(logger, level) option instead
 func ShowSQL(b bool) {
 	showSQL = b
 }
+
+func (o *options) sqlHooks() *hook.Chain {
+	if o.hooks != nil {
+		return o.hooks
+	}
+	if showSQL {
+		return hook.New(hook.NewPrinter(os.Stdout))
+	}
+	return nil
+}
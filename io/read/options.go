@@ -3,6 +3,8 @@ package read
 import (
 	"database/sql"
 	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/metrics"
 	"github.com/viant/sqlx/io/read/cache"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/registry"
@@ -24,7 +26,14 @@ type options struct {
 	cacheRefresh       cache.Refresh
 	inlineType         bool
 	dialect            *info.Dialect
+	hooks              *hook.Chain
+	collector          metrics.Collector
 	options            []option.Option
+	shards             []*option.Shard
+	shardLess          func(prev, next interface{}) bool
+	shardLimit         int
+	tenantColumn       string
+	tenantArg          int
 }
 
 func WithRowMapper(mapper NewRowMapper) Option {
@@ -93,6 +102,32 @@ func WithCacheRefresh(refresh cache.Refresh) Option {
 	}
 }
 
+// WithHooks sets SQL hooks
+func WithHooks(hooks ...hook.Hook) Option {
+	return func(o *options) {
+		o.hooks = hook.New(hooks...)
+	}
+}
+
+// WithCollector sets metrics collector
+func WithCollector(collector metrics.Collector) Option {
+	return func(o *options) {
+		o.collector = collector
+		if o.hooks == nil {
+			o.hooks = hook.New(metrics.NewHook(collector))
+			return
+		}
+		o.hooks = hook.New(o.hooks, metrics.NewHook(collector))
+	}
+}
+
+// WithTenant sets tenant column, simple SELECT query is rewritten with tenant column predicate bound to context tenant ID
+func WithTenant(column string) Option {
+	return func(o *options) {
+		o.tenantColumn = column
+	}
+}
+
 func WithOptions(opts ...option.Option) Option {
 	return func(o *options) {
 		o.options = opts
@@ -133,6 +168,12 @@ func (o *options) applyOptions(opts []option.Option) {
 		o.getRowMapper = newRowMapper
 	}
 	option.Assign(opts, &o.unmappedFn)
+	if hooks := option.Options(opts).Hooks(); hooks != nil {
+		o.hooks = hooks
+	}
+	if collector := option.Options(opts).Collector(); collector != nil {
+		o.collector = collector
+	}
 	for _, anOption := range opts {
 		switch actual := anOption.(type) {
 		case cache.Cache:
@@ -8,10 +8,15 @@ import (
 	goIo "io"
 	"reflect"
 
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/metrics"
 	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/tenant"
 	"github.com/viant/sqlx/option"
 )
 
+var readLabels = metrics.Labels{Source: "read", Op: "query"}
+
 // Reader represents generic query reader
 type (
 	Reader struct {
@@ -34,11 +39,15 @@ type (
 
 // QuerySingle returns single row
 func (r *Reader) QuerySingle(ctx context.Context, emit func(row interface{}) error, args ...interface{}) error {
+	args, err := r.tenantArgs(ctx, args)
+	if err != nil {
+		return err
+	}
 	if err := r.ensureStmt(ctx); err != nil {
 		return err
 	}
 
-	rows, err := r.stmt.QueryContext(ctx, args...)
+	rows, err := r.runQuery(ctx, args)
 	if err != nil {
 		return fmt.Errorf("failed to run query: %v, due to %w", r.query, err)
 	}
@@ -60,6 +69,13 @@ func (r *Reader) QuerySingle(ctx context.Context, emit func(row interface{}) err
 
 // QueryAll query all
 func (r *Reader) QueryAll(ctx context.Context, emit func(row interface{}) error, args ...interface{}) error {
+	args, err := r.tenantArgs(ctx, args)
+	if err != nil {
+		return err
+	}
+	if len(r.shards) > 0 {
+		return r.queryShards(ctx, emit, args)
+	}
 	entry, err := r.cacheEntry(ctx, r.query, args)
 	if err != nil {
 		return fmt.Errorf("failed to cache entry: %w", err)
@@ -91,7 +107,7 @@ func (r *Reader) createSource(ctx context.Context, entry *cache.Entry, args []in
 			return nil, nil, err
 		}
 
-		rows, err := r.stmt.QueryContext(ctx, args...)
+		rows, err := r.runQuery(ctx, args)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to run query: %v, due to %w", r.query, err)
 		}
@@ -130,12 +146,18 @@ func (r *Reader) ReadAll(ctx context.Context, rows *sql.Rows, emit func(row inte
 func (r *Reader) readAll(ctx context.Context, emit func(row interface{}) error, cacheEntry *cache.Entry, source cache.Source) error {
 	var err error
 	var mapper RowMapper
-
+	rowCount := 0
 	for source.Next() && err == nil {
 		err = r.read(ctx, source, &mapper, emit, cacheEntry)
 		if err != nil && !errors.Is(err, sql.ErrNoRows) && !errors.Is(err, goIo.EOF) {
 			err = fmt.Errorf("failed to read row: %w", err)
 		}
+		if err == nil {
+			rowCount++
+		}
+	}
+	if r.collector != nil && rowCount > 0 {
+		r.collector.Count(metrics.Rows, readLabels, float64(rowCount))
 	}
 	if r.row != nil && r.inMatcher != nil && r.inMatcher.OnSkip != nil {
 		_ = r.inMatcher.OnSkip(*r.row.values)
@@ -320,6 +342,24 @@ func (r *Reader) ensureRowMapper(source cache.Source, mapperPtr *RowMapper) (Row
 	return mapper, nil
 }
 
+// tenantArgs inserts context tenant ID into query arguments
+func (r *Reader) tenantArgs(ctx context.Context, args []interface{}) ([]interface{}, error) {
+	if r.tenantColumn == "" {
+		return args, nil
+	}
+	tenantID, err := tenant.RequiredID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.tenantArg > len(args) {
+		return nil, fmt.Errorf("invalid tenant query arguments count: %v, expected at least: %v", len(args), r.tenantArg)
+	}
+	result := make([]interface{}, 0, len(args)+1)
+	result = append(result, args[:r.tenantArg]...)
+	result = append(result, tenantID)
+	return append(result, args[r.tenantArg:]...), nil
+}
+
 // Stmt returns *sql.Stmt associated with Reader
 func (r *Reader) Stmt() *sql.Stmt {
 	return r.stmt
@@ -328,6 +368,13 @@ func (r *Reader) Stmt() *sql.Stmt {
 func (r *Reader) cacheEntry(ctx context.Context, sql string, args []interface{}) (*cache.Entry, error) {
 	if r.cache != nil {
 		entry, err := r.cache.Get(ctx, sql, args, r.inMatcher, r.cacheStats, r.cacheRefresh)
+		if r.collector != nil && err == nil {
+			if entry != nil && entry.Has() {
+				r.collector.Count(metrics.CacheHits, readLabels, 1)
+			} else {
+				r.collector.Count(metrics.CacheMisses, readLabels, 1)
+			}
+		}
 		return entry, err
 	}
 
@@ -347,10 +394,9 @@ func (r *Reader) ensureStmt(ctx context.Context) error {
 		return nil
 	}
 
+	ctx, done := r.sqlHooks().Start(ctx, &hook.Event{Op: hook.OpPrepare, Source: "read", SQL: r.query})
 	stmt, err := r.db.PrepareContext(ctx, r.query)
-	if showSQL {
-		fmt.Println(r.query)
-	}
+	done(0, err)
 	if err != nil {
 		return fmt.Errorf("failed to prepare context: %w", err)
 	}
@@ -359,6 +405,13 @@ func (r *Reader) ensureStmt(ctx context.Context) error {
 	return nil
 }
 
+func (r *Reader) runQuery(ctx context.Context, args []interface{}) (*sql.Rows, error) {
+	ctx, done := r.sqlHooks().Start(ctx, &hook.Event{Op: hook.OpQuery, Source: "read", SQL: r.query, Args: args})
+	rows, err := r.stmt.QueryContext(ctx, args...)
+	done(0, err)
+	return rows, err
+}
+
 func (r *Reader) ensureTargetType(row interface{}) {
 	if r.targetType != nil {
 		return
@@ -373,6 +426,12 @@ func (r *Reader) ensureTargetType(row interface{}) {
 func New(ctx context.Context, db *sql.DB, query string, newRow func() interface{}, options ...Option) (*Reader, error) {
 	options = append(options, WithDB(db))
 	newStmt := NewStmt(nil, newRow, options...)
+	if newStmt.tenantColumn != "" {
+		var err error
+		if query, newStmt.tenantArg, err = tenant.Rewrite(query, newStmt.tenantColumn); err != nil {
+			return nil, err
+		}
+	}
 	if newStmt.dialect != nil {
 		query = newStmt.dialect.EnsurePlaceholders(query)
 	}
@@ -0,0 +1,124 @@
+package read
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/viant/sqlx/option"
+	"strings"
+	"sync"
+)
+
+// ShardSuffix represents query placeholder replaced with shard table suffix
+const ShardSuffix = "$ShardSuffix"
+
+// WithShards sets shards, QueryAll scatters query across shards in parallel and gathers rows in shard order,
+// shard readers do not use cache
+func WithShards(shards ...*option.Shard) Option {
+	return func(o *options) {
+		o.shards = shards
+	}
+}
+
+// WithShardMerge sets gathered rows ordering and limit, each shard query is expected to return rows ordered by the same criteria
+func WithShardMerge(less func(prev, next interface{}) bool, limit int) Option {
+	return func(o *options) {
+		o.shardLess = less
+		o.shardLimit = limit
+	}
+}
+
+// errShardLimit stops shard query once it returned merge limit rows
+var errShardLimit = errors.New("shard limit reached")
+
+func (r *Reader) queryShards(ctx context.Context, emit func(row interface{}) error, args []interface{}) error {
+	readers := r.newShardReaders()
+	defer closeStmts(readers)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var results = make([][]interface{}, len(readers))
+	var firstErr error
+	var once sync.Once
+	var wg sync.WaitGroup
+	for i := range readers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := readers[i].QueryAll(ctx, func(row interface{}) error {
+				results[i] = append(results[i], row)
+				if r.shardLimit > 0 && len(results[i]) >= r.shardLimit { //shard rows are ordered, merge never needs more
+					return errShardLimit
+				}
+				return nil
+			}, args...)
+			if err != nil && !errors.Is(err, errShardLimit) {
+				once.Do(func() {
+					firstErr = fmt.Errorf("failed to query shard %v: %w", r.shards[i].Suffix, err)
+					cancel() //the other shards results are not needed
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	for _, row := range mergeShards(results, r.shardLess, r.shardLimit) {
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newShardReaders creates per call shard readers, since reader lazily prepares statement it can not be shared by concurrent calls
+func (r *Reader) newShardReaders() []*Reader {
+	readers := make([]*Reader, len(r.shards))
+	for i, shard := range r.shards {
+		shardReader := &Reader{options: r.options, query: strings.ReplaceAll(r.query, ShardSuffix, shard.Suffix), newRow: r.newRow}
+		shardReader.db = shard.DB
+		shardReader.shards = nil
+		shardReader.cache = nil
+		shardReader.tenantColumn = "" //tenant ID is already bound to gathered query arguments
+		readers[i] = shardReader
+	}
+	return readers
+}
+
+func closeStmts(readers []*Reader) {
+	for _, reader := range readers {
+		if stmt := reader.Stmt(); stmt != nil {
+			_ = stmt.Close()
+		}
+	}
+}
+
+// mergeShards merges ordered shard rows, or concatenates them in shard order if less is nil
+func mergeShards(results [][]interface{}, less func(prev, next interface{}) bool, limit int) []interface{} {
+	var merged []interface{}
+	var heads = make([]int, len(results))
+	for limit <= 0 || len(merged) < limit {
+		selected := -1
+		for i, rows := range results {
+			if heads[i] >= len(rows) {
+				continue
+			}
+			if selected == -1 {
+				selected = i
+				if less == nil {
+					break
+				}
+				continue
+			}
+			if less(rows[heads[i]], results[selected][heads[selected]]) {
+				selected = i
+			}
+		}
+		if selected == -1 {
+			break
+		}
+		merged = append(merged, results[selected][heads[selected]])
+		heads[selected]++
+	}
+	return merged
+}
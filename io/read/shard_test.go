@@ -0,0 +1,104 @@
+package read_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/option"
+	"path"
+	"sync"
+	"testing"
+)
+
+func TestReader_QueryAll_Shards(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	var shardData = map[string][]int{"_a": {1, 4, 6}, "_b": {2, 3, 8}, "_c": {5, 7}}
+	var shards []*option.Shard
+	for _, suffix := range []string{"_a", "_b", "_c"} {
+		db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "shard"+suffix+".db"))
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer db.Close()
+		_, err = db.Exec("CREATE TABLE entity" + suffix + " (id INTEGER PRIMARY KEY, name TEXT)")
+		assert.Nil(t, err)
+		for _, id := range shardData[suffix] {
+			_, err = db.Exec("INSERT INTO entity"+suffix+" VALUES(?, ?)", id, suffix)
+			assert.Nil(t, err)
+		}
+		shards = append(shards, &option.Shard{DB: db, Suffix: suffix})
+	}
+
+	var testCases = []struct {
+		description string
+		less        func(prev, next interface{}) bool
+		limit       int
+		expect      []int
+	}{
+		{
+			description: "gather in shard order",
+			expect:      []int{1, 4, 6, 2, 3, 8, 5, 7},
+		},
+		{
+			description: "merge ordering",
+			less: func(prev, next interface{}) bool {
+				return prev.(*entity).ID < next.(*entity).ID
+			},
+			expect: []int{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		{
+			description: "merge ordering with limit",
+			less: func(prev, next interface{}) bool {
+				return prev.(*entity).ID < next.(*entity).ID
+			},
+			limit:  3,
+			expect: []int{1, 2, 3},
+		},
+	}
+
+	for _, testCase := range testCases {
+		reader, err := read.New(context.TODO(), shards[0].DB, "SELECT id, name FROM entity"+read.ShardSuffix+" WHERE id > ? ORDER BY id", func() interface{} { return &entity{} },
+			read.WithShards(shards...), read.WithShardMerge(testCase.less, testCase.limit))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actuals = make([][]int, 2)
+		var wg sync.WaitGroup
+		for i := range actuals { //readers share shard readers
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				err := reader.QueryAll(context.TODO(), func(row interface{}) error {
+					actuals[i] = append(actuals[i], row.(*entity).ID)
+					return nil
+				}, 0)
+				assert.Nil(t, err, testCase.description)
+			}(i)
+		}
+		wg.Wait()
+		for _, actual := range actuals {
+			assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		}
+	}
+
+	failing, err := sql.Open("sqlite3", path.Join(t.TempDir(), "shard_failing.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer failing.Close()
+	reader, err := read.New(context.TODO(), shards[0].DB, "SELECT id, name FROM entity"+read.ShardSuffix+" WHERE id > ? ORDER BY id", func() interface{} { return &entity{} },
+		read.WithShards(append(shards, &option.Shard{DB: failing, Suffix: "_d"})...))
+	if !assert.Nil(t, err) {
+		return
+	}
+	err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+		return nil
+	}, 0)
+	if assert.NotNil(t, err, "missing shard table") {
+		assert.Contains(t, err.Error(), "failed to query shard _d", "missing shard table")
+	}
+}
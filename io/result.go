@@ -1,12 +1,16 @@
 package io
 
-import "database/sql"
+import (
+	"database/sql"
+	goIo "io"
+)
 
 // QueryResult summarizes an executed SQL command.
 // use instead of standard Result when you need omit bug: "0 affected rows"
 type QueryResult struct {
 	sql.Result
 	Rows  int64
+	Bytes int64
 	Error error
 }
 
@@ -14,3 +18,21 @@ type QueryResult struct {
 func (r *QueryResult) RowsAffected() (int64, error) {
 	return r.Rows, r.Error
 }
+
+// BytesLoaded returns count of bytes streamed to database
+func (r *QueryResult) BytesLoaded() int64 {
+	return r.Bytes
+}
+
+// CountingReader represents reader counting read bytes
+type CountingReader struct {
+	goIo.Reader
+	Count int64
+}
+
+// Read reads data from underlying reader
+func (r *CountingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.Count += int64(n)
+	return n, err
+}
@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"context"
+	"github.com/viant/sqlx/io/errx"
+	"github.com/viant/sqlx/io/metrics"
+	"github.com/viant/sqlx/option"
+	"math/rand"
+	"time"
+)
+
+// Policy represents write service retry policy, it re-runs the whole service owned transaction on retryable failure.
+// Policy is ignored when caller supplied global or external *sql.Tx, since only the caller can re-run its transaction.
+type Policy struct {
+	MaxAttempts int           //total number of attempts, including the first one
+	Backoff     time.Duration //initial delay between attempts
+	MaxBackoff  time.Duration //max delay between attempts, 0 - no limit
+	Multiplier  float64       //delay multiplier, defaults to 2
+	Jitter      float64       //delay randomization factor between 0 and 1
+	Retryable   func(err error) bool
+}
+
+// New creates retry policy option with max attempts and initial backoff, retrying transient errors (deadlock,
+// serialization failure, lock timeout or lost connection), policy is ignored with caller supplied *sql.Tx
+func New(maxAttempts int, backoff time.Duration) *Policy {
+	return &Policy{MaxAttempts: maxAttempts, Backoff: backoff, Jitter: 0.2}
+}
+
+// IsRetryPolicy implements option.RetryPolicy marker
+func (p *Policy) IsRetryPolicy() {}
+
+// IsRetryable returns true if error is retryable
+func (p *Policy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return errx.IsTransient(err)
+}
+
+// Delay returns delay before supplied attempt (starting from 2)
+func (p *Policy) Delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(p.Backoff)
+	for i := 2; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// Run runs fn till it succeeds, returns non retryable error or exhausts max attempts
+func (p *Policy) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p == nil {
+		return fn(ctx)
+	}
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= p.MaxAttempts || !p.IsRetryable(err) {
+			return err
+		}
+		timer := time.NewTimer(p.Delay(attempt + 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// RunObserved runs fn with Run, reporting retries to collector
+func (p *Policy) RunObserved(ctx context.Context, collector metrics.Collector, labels metrics.Labels, fn func(ctx context.Context) error) error {
+	attempts := 0
+	err := p.Run(ctx, func(ctx context.Context) error {
+		attempts++
+		return fn(ctx)
+	})
+	if collector != nil && attempts > 1 {
+		collector.Count(metrics.Retries, labels, float64(attempts-1))
+	}
+	return err
+}
+
+// Owned returns retry policy for service owned transaction, or nil if caller supplied global *sql.Tx
+func Owned(options option.Options) *Policy {
+	policy, _ := options.OwnedRetryPolicy().(*Policy)
+	return policy
+}
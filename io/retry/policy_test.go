@@ -0,0 +1,92 @@
+package retry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/errx"
+	"github.com/viant/sqlx/option"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Run(t *testing.T) {
+	deadlock := &errx.Error{Kind: errx.ErrDeadlock, Op: "update", Table: "foo"}
+	var testCases = []struct {
+		description    string
+		policy         *Policy
+		errors         []error
+		expectAttempts int
+		expectErr      bool
+	}{
+		{
+			description:    "transient error retried till success",
+			policy:         New(3, time.Millisecond),
+			errors:         []error{deadlock, deadlock, nil},
+			expectAttempts: 3,
+		},
+		{
+			description:    "max attempts exhausted",
+			policy:         New(2, time.Millisecond),
+			errors:         []error{deadlock, deadlock, nil},
+			expectAttempts: 2,
+			expectErr:      true,
+		},
+		{
+			description:    "non retryable error",
+			policy:         New(3, time.Millisecond),
+			errors:         []error{fmt.Errorf("syntax error"), nil},
+			expectAttempts: 1,
+			expectErr:      true,
+		},
+		{
+			description: "custom classifier",
+			policy: &Policy{MaxAttempts: 3, Retryable: func(err error) bool {
+				return errx.IsDuplicateKey(err)
+			}},
+			errors:         []error{errors.New("duplicate key value"), nil},
+			expectAttempts: 2,
+		},
+	}
+	for _, testCase := range testCases {
+		attempts := 0
+		err := testCase.policy.Run(context.Background(), func(ctx context.Context) error {
+			err := testCase.errors[attempts]
+			attempts++
+			return err
+		})
+		assert.EqualValues(t, testCase.expectAttempts, attempts, testCase.description)
+		assert.EqualValues(t, testCase.expectErr, err != nil, testCase.description)
+	}
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	policy := &Policy{Backoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	assert.EqualValues(t, 10*time.Millisecond, policy.Delay(2))
+	assert.EqualValues(t, 20*time.Millisecond, policy.Delay(3))
+	assert.EqualValues(t, 40*time.Millisecond, policy.Delay(4))
+	assert.EqualValues(t, 50*time.Millisecond, policy.Delay(10))
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.Delay(2)
+		assert.True(t, delay >= 5*time.Millisecond && delay <= 15*time.Millisecond)
+	}
+}
+
+func TestOwned(t *testing.T) {
+	policy := New(3, time.Millisecond)
+	var testCases = []struct {
+		description string
+		options     option.Options
+		expect      *Policy
+	}{
+		{description: "service owned transaction", options: option.Options{policy}, expect: policy},
+		{description: "caller supplied transaction", options: option.Options{policy, &sql.Tx{}}},
+		{description: "no policy", options: option.Options{option.BatchSize(1)}},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Owned(testCase.options), testCase.description)
+	}
+}
@@ -0,0 +1,164 @@
+package router
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+type (
+	connector struct {
+		router *Router
+	}
+
+	routerDriver struct {
+		connector *connector
+	}
+
+	// conn represents routed connection, it delegates to primary or replica pools, or pinned primary transaction
+	conn struct {
+		router *Router
+		tx     *sql.Tx
+	}
+
+	transaction struct {
+		conn *conn
+	}
+
+	queryer interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	}
+)
+
+// Connect returns routed connection
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &conn{router: c.router}, nil
+}
+
+// Driver returns router driver
+func (c *connector) Driver() driver.Driver {
+	return &routerDriver{connector: c}
+}
+
+// Open returns routed connection
+func (d *routerDriver) Open(name string) (driver.Conn, error) {
+	return d.connector.Connect(context.Background())
+}
+
+// Unwrap returns primary driver, used for product detection
+func (d *routerDriver) Unwrap() driver.Driver {
+	return d.connector.router.primary.Driver()
+}
+
+// Prepare prepares statement
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares statement on transaction, replica for read only statement, or primary
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ret := &stmt{conn: c, query: query, read: IsRead(query), prepared: map[queryer]*sql.Stmt{}}
+	if _, err := ret.ensure(ctx); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ExecContext executes statement on transaction or primary
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	markWritten(ctx)
+	return c.target(ctx, false).ExecContext(ctx, query, values(args)...)
+}
+
+// QueryContext runs query on transaction, replica for read only statement, or primary
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	isRead := IsRead(query)
+	if !isRead {
+		markWritten(ctx)
+	}
+	sqlRows, err := c.target(ctx, isRead).QueryContext(ctx, query, values(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(sqlRows)
+}
+
+// CheckNamedValue passes arguments unchanged to underlying driver
+func (c *conn) CheckNamedValue(value *driver.NamedValue) error {
+	return nil
+}
+
+// Ping pings primary
+func (c *conn) Ping(ctx context.Context) error {
+	return c.router.primary.PingContext(ctx)
+}
+
+// Begin starts primary transaction
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts primary transaction
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.tx != nil {
+		return nil, fmt.Errorf("transaction already started")
+	}
+	if !opts.ReadOnly {
+		markWritten(ctx)
+	}
+	tx, err := c.router.primary.BeginTx(ctx, &sql.TxOptions{Isolation: sql.IsolationLevel(opts.Isolation), ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	c.tx = tx
+	return &transaction{conn: c}, nil
+}
+
+// Close closes connection, rolling back pending transaction
+func (c *conn) Close() error {
+	if c.tx != nil {
+		err := c.tx.Rollback()
+		c.tx = nil
+		return err
+	}
+	return nil
+}
+
+func (c *conn) target(ctx context.Context, isRead bool) queryer {
+	if c.tx != nil {
+		return c.tx
+	}
+	if isRead {
+		return c.router.Replica(ctx)
+	}
+	return c.router.primary
+}
+
+// Commit commits primary transaction
+func (t *transaction) Commit() error {
+	tx := t.conn.tx
+	t.conn.tx = nil
+	return tx.Commit()
+}
+
+// Rollback rolls back primary transaction
+func (t *transaction) Rollback() error {
+	tx := t.conn.tx
+	t.conn.tx = nil
+	return tx.Rollback()
+}
+
+func values(args []driver.NamedValue) []interface{} {
+	var result = make([]interface{}, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			result[i] = sql.Named(arg.Name, arg.Value)
+			continue
+		}
+		result[i] = arg.Value
+	}
+	return result
+}
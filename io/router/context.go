@@ -0,0 +1,49 @@
+package router
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type (
+	primaryKey struct{}
+	sessionKey struct{}
+
+	session struct {
+		written int32
+	}
+)
+
+// WithPrimary returns context routing all statements to primary
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// ReadYourWrites returns context routing reads to primary once any write was issued with the returned context
+func ReadYourWrites(ctx context.Context) context.Context {
+	if sessionFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+func isPrimary(ctx context.Context) bool {
+	if value, ok := ctx.Value(primaryKey{}).(bool); ok && value {
+		return true
+	}
+	if aSession := sessionFrom(ctx); aSession != nil {
+		return atomic.LoadInt32(&aSession.written) == 1
+	}
+	return false
+}
+
+func markWritten(ctx context.Context) {
+	if aSession := sessionFrom(ctx); aSession != nil {
+		atomic.StoreInt32(&aSession.written, 1)
+	}
+}
+
+func sessionFrom(ctx context.Context) *session {
+	aSession, _ := ctx.Value(sessionKey{}).(*session)
+	return aSession
+}
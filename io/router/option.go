@@ -0,0 +1,43 @@
+package router
+
+import "time"
+
+// Policy represents replica selection policy
+type Policy string
+
+const (
+	// RoundRobin selects eligible replicas in turn
+	RoundRobin = Policy("roundRobin")
+	// LeastLatency selects eligible replica with the lowest health check latency
+	LeastLatency = Policy("leastLatency")
+)
+
+// DefaultCheckTimeout represents default health check timeout
+const DefaultCheckTimeout = 5 * time.Second
+
+// Option represents router option
+type Option func(r *Router)
+
+// WithPolicy sets replica selection policy, RoundRobin by default
+func WithPolicy(policy Policy) Option {
+	return func(r *Router) {
+		r.policy = policy
+	}
+}
+
+// WithMaxLag sets replication lag threshold, replicas lagging more are skipped
+func WithMaxLag(maxLag time.Duration) Option {
+	return func(r *Router) {
+		r.maxLag = maxLag
+	}
+}
+
+// WithHealthCheck sets background health check interval and timeout
+func WithHealthCheck(interval, timeout time.Duration) Option {
+	return func(r *Router) {
+		r.interval = interval
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package router
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/registry"
+	"github.com/viant/sqlx/metadata/sink"
+	"sync"
+	"time"
+)
+
+type (
+	// Status represents replica health check status
+	Status struct {
+		Healthy bool
+		Latency time.Duration
+		Lag     time.Duration
+		Err     error
+		Checked time.Time
+	}
+
+	replica struct {
+		db     *sql.DB
+		meta   *metadata.Service
+		status Status
+		mux    sync.RWMutex
+	}
+)
+
+func (r *replica) check(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	status := Status{Checked: time.Now()}
+	started := time.Now()
+	status.Err = r.db.PingContext(ctx)
+	status.Latency = time.Since(started)
+	if status.Err == nil {
+		status.Lag, status.Err = r.lag(ctx)
+	}
+	status.Healthy = status.Err == nil
+	r.mux.Lock()
+	r.status = status
+	r.mux.Unlock()
+}
+
+// lag returns replication lag, zero if product does not define replication lag query, error if replication is not running
+func (r *replica) lag(ctx context.Context) (time.Duration, error) {
+	product, err := r.meta.DetectProduct(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+	if len(registry.Lookup(product.Name, info.KindReplicationLag)) == 0 {
+		return 0, nil
+	}
+	replication := sink.Replication{}
+	if err = r.meta.Info(ctx, r.db, info.KindReplicationLag, &replication, product); err != nil {
+		return 0, err
+	}
+	if replication.Stopped {
+		return 0, fmt.Errorf("replication is not running")
+	}
+	return time.Duration(replication.LagSeconds * float64(time.Second)), nil
+}
+
+func (r *replica) snapshot() Status {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return r.status
+}
+
+func (r *replica) eligible(maxLag time.Duration) bool {
+	status := r.snapshot()
+	return status.Healthy && (maxLag == 0 || status.Lag <= maxLag)
+}
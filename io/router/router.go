@@ -0,0 +1,142 @@
+package router
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Router represents read/write splitting router, it routes read only statements to healthy replicas
+// within replication lag threshold, and all other statements and transactions to primary.
+//
+// DB returns *sql.DB backed by the router, accepted wherever services take *sql.DB: read.Reader routes to replicas,
+// while insert, update, delete, load and merge services always use primary.
+type Router struct {
+	primary  *sql.DB
+	replicas []*replica
+	policy   Policy
+	maxLag   time.Duration
+	interval time.Duration
+	timeout  time.Duration
+	counter  uint32
+	db       *sql.DB
+	checkMux sync.Mutex
+	closed   chan bool
+	done     sync.WaitGroup
+}
+
+// DB returns routed *sql.DB
+func (r *Router) DB() *sql.DB {
+	return r.db
+}
+
+// Primary returns primary *sql.DB
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Replica returns replica selected with router policy for context, or primary if no replica is eligible
+func (r *Router) Replica(ctx context.Context) *sql.DB {
+	if isPrimary(ctx) {
+		return r.primary
+	}
+	var candidates []*replica
+	for _, candidate := range r.replicas {
+		if candidate.eligible(r.maxLag) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return r.primary
+	case 1:
+		return candidates[0].db
+	}
+	if r.policy == LeastLatency {
+		selected := candidates[0]
+		latency := selected.snapshot().Latency
+		for _, candidate := range candidates[1:] {
+			if candidateLatency := candidate.snapshot().Latency; candidateLatency < latency {
+				selected, latency = candidate, candidateLatency
+			}
+		}
+		return selected.db
+	}
+	index := atomic.AddUint32(&r.counter, 1) - 1
+	return candidates[int(index)%len(candidates)].db
+}
+
+// Check runs replicas health check
+func (r *Router) Check(ctx context.Context) {
+	r.checkMux.Lock()
+	defer r.checkMux.Unlock()
+	var wg sync.WaitGroup
+	for _, candidate := range r.replicas {
+		wg.Add(1)
+		go func(candidate *replica) {
+			defer wg.Done()
+			candidate.check(ctx, r.timeout)
+		}(candidate)
+	}
+	wg.Wait()
+}
+
+// Status returns replicas status in construction order
+func (r *Router) Status() []Status {
+	var result = make([]Status, len(r.replicas))
+	for i, candidate := range r.replicas {
+		result[i] = candidate.snapshot()
+	}
+	return result
+}
+
+// Close stops health checks and closes routed *sql.DB, primary and replicas are not closed
+func (r *Router) Close() error {
+	select {
+	case <-r.closed:
+		return nil
+	default:
+		close(r.closed)
+	}
+	r.done.Wait()
+	return r.db.Close()
+}
+
+func (r *Router) runChecks() {
+	defer r.done.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.closed:
+			return
+		case <-ticker.C:
+			r.Check(context.Background())
+		}
+	}
+}
+
+// New creates a router, replicas are checked before returning
+func New(ctx context.Context, primary *sql.DB, replicas []*sql.DB, options ...Option) (*Router, error) {
+	if primary == nil {
+		return nil, fmt.Errorf("primary db was nil")
+	}
+	ret := &Router{primary: primary, policy: RoundRobin, timeout: DefaultCheckTimeout, closed: make(chan bool)}
+	for _, db := range replicas {
+		ret.replicas = append(ret.replicas, &replica{db: db, meta: metadata.New()})
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	ret.db = sql.OpenDB(&connector{router: ret})
+	ret.Check(ctx)
+	if ret.interval > 0 && len(ret.replicas) > 0 {
+		ret.done.Add(1)
+		go ret.runChecks()
+	}
+	return ret, nil
+}
@@ -0,0 +1,131 @@
+package router_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/router"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"path"
+	"testing"
+)
+
+type entity struct {
+	ID   int    `sqlx:"name=id,primaryKey=true"`
+	Name string `sqlx:"name"`
+}
+
+func TestRouter_DB(t *testing.T) {
+	var testCases = []struct {
+		description   string
+		policy        router.Policy
+		closeReplica  bool
+		readYourWrite bool
+		expectBefore  string
+		expectAfter   string
+	}{
+		{
+			description:  "reads are routed to replica, writes to primary",
+			policy:       router.RoundRobin,
+			expectBefore: "replica",
+			expectAfter:  "replica",
+		},
+		{
+			description:   "read your writes routes reads to primary after write",
+			policy:        router.LeastLatency,
+			readYourWrite: true,
+			expectBefore:  "replica",
+			expectAfter:   "primary",
+		},
+		{
+			description:  "unhealthy replica falls back to primary",
+			policy:       router.RoundRobin,
+			closeReplica: true,
+			expectBefore: "primary",
+			expectAfter:  "primary",
+		},
+	}
+
+	for _, testCase := range testCases {
+		primary := openDB(t, "primary")
+		replica := openDB(t, "replica")
+		if testCase.closeReplica {
+			_ = replica.Close()
+		}
+		aRouter, err := router.New(context.TODO(), primary, []*sql.DB{replica}, router.WithPolicy(testCase.policy))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, !testCase.closeReplica, aRouter.Status()[0].Healthy, testCase.description)
+		ctx := context.Background()
+		if testCase.readYourWrite {
+			ctx = router.ReadYourWrites(ctx)
+		}
+		assert.EqualValues(t, testCase.expectBefore, readName(t, ctx, aRouter.DB()), testCase.description)
+
+		inserter, err := insert.New(ctx, aRouter.DB(), "entity")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, _, err = inserter.Exec(ctx, []*entity{{ID: 2, Name: "inserted"}})
+		assert.Nil(t, err, testCase.description)
+		var count int
+		err = primary.QueryRow("SELECT COUNT(*) FROM entity").Scan(&count)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, 2, count, testCase.description)
+
+		assert.EqualValues(t, testCase.expectAfter, readName(t, ctx, aRouter.DB()), testCase.description)
+		assert.Nil(t, aRouter.Close(), testCase.description)
+		_ = primary.Close()
+		_ = replica.Close()
+	}
+}
+
+func TestIsRead(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      bool
+	}{
+		{description: "select", SQL: "SELECT * FROM t WHERE id = ?", expect: true},
+		{description: "comment and parenthesis", SQL: "/* q1 */ (SELECT 1) UNION (SELECT 2)", expect: true},
+		{description: "cte", SQL: "WITH x AS (SELECT 1) SELECT * FROM x", expect: true},
+		{description: "select for update", SQL: "SELECT * FROM t FOR UPDATE", expect: false},
+		{description: "data modifying cte", SQL: "WITH x AS (DELETE FROM t RETURNING id) SELECT * FROM x", expect: false},
+		{description: "insert", SQL: "INSERT INTO t(id) VALUES(1)", expect: false},
+		{description: "update", SQL: "-- update\nUPDATE t SET id = 1", expect: false},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, router.IsRead(testCase.SQL), testCase.description)
+	}
+}
+
+func openDB(t *testing.T, name string) *sql.DB {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), name+".db"))
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	for _, SQL := range []string{"CREATE TABLE entity (id INTEGER PRIMARY KEY, name TEXT)", "INSERT INTO entity VALUES(1, '" + name + "')"} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			t.FailNow()
+		}
+	}
+	return db
+}
+
+func readName(t *testing.T, ctx context.Context, db *sql.DB) string {
+	reader, err := read.New(ctx, db, "SELECT id, name FROM entity WHERE id = ?", func() interface{} { return &entity{} })
+	if !assert.Nil(t, err) {
+		return ""
+	}
+	var result string
+	err = reader.QueryAll(ctx, func(row interface{}) error {
+		result = row.(*entity).Name
+		return nil
+	}, 1)
+	assert.Nil(t, err)
+	return result
+}
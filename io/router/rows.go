@@ -0,0 +1,90 @@
+package router
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+)
+
+// rows represents driver rows adapter of underlying *sql.Rows
+type rows struct {
+	rows     *sql.Rows
+	columns  []string
+	types    []*sql.ColumnType
+	values   []interface{}
+	pointers []interface{}
+}
+
+// Columns returns column names
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+// Close closes underlying rows
+func (r *rows) Close() error {
+	return r.rows.Close()
+}
+
+// Next reads next row
+func (r *rows) Next(dest []driver.Value) error {
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	if err := r.rows.Scan(r.pointers...); err != nil {
+		return err
+	}
+	for i := range dest {
+		dest[i] = r.values[i]
+	}
+	return nil
+}
+
+// ColumnTypeScanType returns column scan type
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if scanType := r.types[index].ScanType(); scanType != nil {
+		return scanType
+	}
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+// ColumnTypeDatabaseTypeName returns column database type name
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index].DatabaseTypeName()
+}
+
+// ColumnTypeNullable returns column nullability
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return r.types[index].Nullable()
+}
+
+// ColumnTypeLength returns column length
+func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	return r.types[index].Length()
+}
+
+// ColumnTypePrecisionScale returns column precision and scale
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	return r.types[index].DecimalSize()
+}
+
+func newRows(sqlRows *sql.Rows) (*rows, error) {
+	columns, err := sqlRows.Columns()
+	if err != nil {
+		_ = sqlRows.Close()
+		return nil, err
+	}
+	types, err := sqlRows.ColumnTypes()
+	if err != nil {
+		_ = sqlRows.Close()
+		return nil, err
+	}
+	ret := &rows{rows: sqlRows, columns: columns, types: types, values: make([]interface{}, len(columns)), pointers: make([]interface{}, len(columns))}
+	for i := range ret.values {
+		ret.pointers[i] = &ret.values[i]
+	}
+	return ret, nil
+}
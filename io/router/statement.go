@@ -0,0 +1,70 @@
+package router
+
+import (
+	"strings"
+)
+
+var readKeywords = []string{"SELECT", "WITH", "SHOW", "EXPLAIN", "DESCRIBE", "DESC", "VALUES", "PRAGMA"}
+
+var writeKeywords = []string{"INSERT", "UPDATE", "DELETE", "MERGE", "UPSERT", "REPLACE"}
+
+// IsRead returns true if SQL is read only statement, that can be routed to replica
+func IsRead(SQL string) bool {
+	normalized := strings.ToUpper(trimLeading(SQL))
+	keyword := normalized
+	if index := strings.IndexAny(normalized, " \t\r\n("); index != -1 {
+		keyword = normalized[:index]
+	}
+	isRead := false
+	for _, candidate := range readKeywords {
+		if keyword == candidate {
+			isRead = true
+			break
+		}
+	}
+	if !isRead {
+		return false
+	}
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ", ";", " ").Replace(normalized))
+	for i, field := range fields {
+		switch field {
+		case "FOR": //SELECT ... FOR UPDATE/SHARE
+			if i+1 < len(fields) && (fields[i+1] == "UPDATE" || fields[i+1] == "SHARE" || fields[i+1] == "NO") {
+				return false
+			}
+		case "INTO", "LOCK": //SELECT ... INTO, LOCK IN SHARE MODE
+			return false
+		}
+		if keyword == "WITH" || keyword == "EXPLAIN" {
+			for _, candidate := range writeKeywords {
+				if field == candidate {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// trimLeading removes leading white spaces, comments and parenthesis
+func trimLeading(SQL string) string {
+	for {
+		SQL = strings.TrimLeft(SQL, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(SQL, "--"):
+			index := strings.Index(SQL, "\n")
+			if index == -1 {
+				return ""
+			}
+			SQL = SQL[index+1:]
+		case strings.HasPrefix(SQL, "/*"):
+			index := strings.Index(SQL, "*/")
+			if index == -1 {
+				return ""
+			}
+			SQL = SQL[index+2:]
+		default:
+			return SQL
+		}
+	}
+}
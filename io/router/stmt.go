@@ -0,0 +1,97 @@
+package router
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// stmt represents routed statement, it is prepared lazily on target selected for each execution context
+type stmt struct {
+	conn     *conn
+	query    string
+	read     bool
+	prepared map[queryer]*sql.Stmt
+}
+
+// Close closes underlying statements
+func (s *stmt) Close() error {
+	var err error
+	for _, prepared := range s.prepared {
+		if closeErr := prepared.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	s.prepared = nil
+	return err
+}
+
+// NumInput returns -1, arguments are checked by underlying driver
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+// Exec executes statement
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), named(args))
+}
+
+// Query runs query
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), named(args))
+}
+
+// ExecContext executes statement on transaction or primary
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	markWritten(ctx)
+	prepared, err := s.ensureOn(ctx, s.conn.target(ctx, false))
+	if err != nil {
+		return nil, err
+	}
+	return prepared.ExecContext(ctx, values(args)...)
+}
+
+// QueryContext runs query on transaction, replica for read only statement, or primary
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if !s.read {
+		markWritten(ctx)
+	}
+	prepared, err := s.ensure(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sqlRows, err := prepared.QueryContext(ctx, values(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(sqlRows)
+}
+
+// CheckNamedValue passes arguments unchanged to underlying driver
+func (s *stmt) CheckNamedValue(value *driver.NamedValue) error {
+	return nil
+}
+
+func (s *stmt) ensure(ctx context.Context) (*sql.Stmt, error) {
+	return s.ensureOn(ctx, s.conn.target(ctx, s.read))
+}
+
+func (s *stmt) ensureOn(ctx context.Context, target queryer) (*sql.Stmt, error) {
+	if prepared, ok := s.prepared[target]; ok {
+		return prepared, nil
+	}
+	prepared, err := target.PrepareContext(ctx, s.query)
+	if err != nil {
+		return nil, err
+	}
+	s.prepared[target] = prepared
+	return prepared, nil
+}
+
+func named(args []driver.Value) []driver.NamedValue {
+	var result = make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		result[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package io
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/option"
+	"sync"
+)
+
+// ShardGroup represents records resolved to the same shard
+type ShardGroup struct {
+	Shard   option.Shard
+	Records []interface{}
+}
+
+// GroupByShard splits records by shard preserving records and first seen shard order
+func GroupByShard(any interface{}, resolver option.ShardResolver) ([]*ShardGroup, error) {
+	valueAt, size, err := Values(any)
+	if err != nil {
+		return nil, err
+	}
+	var groups []*ShardGroup
+	var index = map[option.Shard]*ShardGroup{}
+	for i := 0; i < size; i++ {
+		record := valueAt(i)
+		shard, err := resolver(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve shard for record %v: %w", i, err)
+		}
+		if shard == nil || shard.DB == nil {
+			return nil, fmt.Errorf("failed to resolve shard for record %v: shard db was nil", i)
+		}
+		group, ok := index[*shard]
+		if !ok {
+			group = &ShardGroup{Shard: *shard}
+			index[*shard] = group
+			groups = append(groups, group)
+		}
+		group.Records = append(group.Records, record)
+	}
+	return groups, nil
+}
+
+// RunShards runs fn for each shard group in parallel and returns first error
+func RunShards(groups []*ShardGroup, fn func(group *ShardGroup) error) error {
+	var errs = make([]error, len(groups))
+	var wg sync.WaitGroup
+	for i := range groups {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := fn(groups[i]); err != nil {
+				errs[i] = fmt.Errorf("shard %v: %w", groups[i].Shard.Suffix, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
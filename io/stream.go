@@ -0,0 +1,117 @@
+package io
+
+import (
+	"fmt"
+	goIo "io"
+	"reflect"
+)
+
+type (
+	//RecordStream represents streamed records source, Next returns io.EOF once there are no more records, nil record is reported as error
+	RecordStream struct {
+		Type  reflect.Type
+		next  func() (interface{}, error)
+		count int
+	}
+
+	//EncodedStream represents already encoded load data (i.e. CSV encoded with product load config), Type is used to derive load columns
+	EncodedStream struct {
+		Type   reflect.Type
+		Reader goIo.Reader
+	}
+)
+
+//Next returns next record or io.EOF
+func (s *RecordStream) Next() (interface{}, error) {
+	record, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	if isNilRecord(record) {
+		return nil, fmt.Errorf("invalid record stream: nil record at position %v", s.count)
+	}
+	s.count++
+	return record, nil
+}
+
+//Count returns number of records read so far
+func (s *RecordStream) Count() int {
+	return s.count
+}
+
+//StructType returns dereferenced record type
+func (s *RecordStream) StructType() reflect.Type {
+	return derefType(s.Type)
+}
+
+//StructType returns dereferenced record type
+func (s *EncodedStream) StructType() reflect.Type {
+	return derefType(s.Type)
+}
+
+//NewRecordStream creates record stream for supplied iterator
+func NewRecordStream(recordType reflect.Type, next func() (interface{}, error)) *RecordStream {
+	return &RecordStream{Type: recordType, next: next}
+}
+
+//NewChanRecordStream creates record stream for supplied channel (i.e. chan *Foo), stream ends once channel gets closed
+func NewChanRecordStream(channel interface{}) (*RecordStream, error) {
+	chanValue := reflect.ValueOf(channel)
+	if chanValue.Kind() != reflect.Chan || chanValue.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, fmt.Errorf("invalid record stream channel: expected receive channel, but had %T", channel)
+	}
+
+	recordType := chanValue.Type().Elem()
+	return NewRecordStream(recordType, func() (interface{}, error) {
+		value, ok := chanValue.Recv()
+		if !ok {
+			return nil, goIo.EOF
+		}
+		return value.Interface(), nil
+	}), nil
+}
+
+//NewEncodedStream creates encoded load data stream
+func NewEncodedStream(recordType reflect.Type, reader goIo.Reader) *EncodedStream {
+	return &EncodedStream{Type: recordType, Reader: reader}
+}
+
+//StreamOf returns record stream for supplied data, data can be already a *RecordStream or anything supported by Values
+func StreamOf(data interface{}) (*RecordStream, error) {
+	if stream, ok := data.(*RecordStream); ok {
+		return stream, nil
+	}
+
+	valueAt, size, err := Values(data)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, fmt.Errorf("unable to create record stream - empty input data")
+	}
+
+	index := 0
+	return NewRecordStream(reflect.TypeOf(valueAt(0)), func() (interface{}, error) {
+		if index >= size {
+			return nil, goIo.EOF
+		}
+		record := valueAt(index)
+		index++
+		return record, nil
+	}), nil
+}
+
+func isNilRecord(record interface{}) bool {
+	if record == nil {
+		return true
+	}
+	value := reflect.ValueOf(record)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+func derefType(rType reflect.Type) reflect.Type {
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType
+}
@@ -0,0 +1,55 @@
+package io
+
+import (
+	"github.com/stretchr/testify/assert"
+	goIo "io"
+	"reflect"
+	"testing"
+)
+
+func TestNewChanRecordStream(t *testing.T) {
+	type Foo struct {
+		ID int
+	}
+
+	records := make(chan *Foo, 3)
+	records <- &Foo{ID: 1}
+	records <- &Foo{ID: 2}
+	close(records)
+
+	stream, err := NewChanRecordStream(records)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, reflect.TypeOf(Foo{}), stream.StructType())
+
+	var actual []int
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+		actual = append(actual, record.(*Foo).ID)
+	}
+	assert.Equal(t, []int{1, 2}, actual)
+	assert.Equal(t, 2, stream.Count())
+
+	_, err = NewChanRecordStream([]*Foo{})
+	assert.NotNil(t, err)
+
+	withNil := make(chan *Foo, 2)
+	withNil <- &Foo{ID: 1}
+	withNil <- nil
+	close(withNil)
+	stream, err = NewChanRecordStream(withNil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = stream.Next()
+	assert.Nil(t, err)
+	_, err = stream.Next()
+	assert.EqualError(t, err, "invalid record stream: nil record at position 1")
+}
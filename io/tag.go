@@ -26,16 +26,26 @@ type Tag struct {
 	Generator        string
 	IsUnique         bool
 	UniqueDep        string
+	UniqueKey        string
 	Db               string
 	Table            string
 	RefDb            string
 	RefTable         string
 	RefColumn        string
+	RefKey           string
 	Required         bool
 	OmitEmpty        bool
 	NullifyEmpty     bool
 	ErrorMgs         string
+	Min              string
+	Max              string
+	MinLength        string
+	MaxLength        string
+	Pattern          string
+	OneOf            []string
+	Comparisons      []*Comparison
 	PresenceProvider bool
+	Tenant           bool
 	Bit              bool
 	Encoding         string
 	CaseFormat       text.CaseFormat
@@ -43,6 +53,12 @@ type Tag struct {
 	Raw              string
 }
 
+// Comparison represents cross field comparison, i.e. gtField=StartDate
+type Comparison struct {
+	Op    string
+	Field string
+}
+
 // CanExpand return true if field can expend fied struct fields
 func (f *Field) CanExpand() bool {
 	if f.Tag.Ns != "" {
@@ -82,6 +98,10 @@ func ParseTag(structTag reflect.StructTag) *Tag {
 
 	values := tags.Values(tagString)
 	name, values := values.Name()
+	if name == "tenant" { //bare tenant flag, column name is derived from field name, use name=tenant for tenant column
+		tag.Tenant = true
+		name = ""
+	}
 	tag.Column = name
 	_ = values.MatchPairs(tag.updateTagKey)
 	tag.PrimaryKey = tag.PrimaryKey || tag.Autoincrement
@@ -105,6 +125,8 @@ func (t *Tag) updateTagKey(key string, value string) error {
 	case "uniquedep":
 		t.UniqueDep = strings.TrimSpace(value)
 		t.IsUnique = true
+	case "uniquekey":
+		t.UniqueKey = strings.TrimSpace(value)
 	case "unique":
 		t.IsUnique = strings.TrimSpace(value) == "true" || strings.TrimSpace(value) == ""
 	case "db":
@@ -119,8 +141,12 @@ func (t *Tag) updateTagKey(key string, value string) error {
 		t.RefTable = value
 	case "refcolumn":
 		t.RefColumn = value
+	case "refkey":
+		t.RefKey = strings.TrimSpace(value)
 	case "transient":
 		t.Transient = strings.TrimSpace(value) == "true" || strings.TrimSpace(value) == ""
+	case "tenant":
+		t.Tenant = strings.TrimSpace(value) == "true" || strings.TrimSpace(value) == ""
 	case "bit":
 		t.Bit = strings.TrimSpace(value) == "true" || strings.TrimSpace(value) == ""
 	case "type":
@@ -131,6 +157,23 @@ func (t *Tag) updateTagKey(key string, value string) error {
 		t.Required = strings.TrimSpace(value) == "true" || strings.TrimSpace(value) == ""
 	case "errormsg":
 		t.ErrorMgs = strings.ReplaceAll(value, "$coma", ",")
+	case "min":
+		t.Min = strings.TrimSpace(value)
+	case "max":
+		t.Max = strings.TrimSpace(value)
+	case "minlength", "minlen":
+		t.MinLength = strings.TrimSpace(value)
+	case "maxlength", "maxlen":
+		t.MaxLength = strings.TrimSpace(value)
+	case "pattern", "regex":
+		t.Pattern = strings.ReplaceAll(unquote(value), "$coma", ",")
+	case "oneof":
+		for _, item := range strings.Split(unquote(value), "|") {
+			t.OneOf = append(t.OneOf, strings.ReplaceAll(item, "$coma", ","))
+		}
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		op := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(key)), "field")
+		t.Comparisons = append(t.Comparisons, &Comparison{Op: op, Field: strings.TrimSpace(value)})
 	case "generator":
 		generatorStrat := strings.TrimSpace(value)
 		t.Generator = generatorStrat
@@ -149,6 +192,13 @@ func (t *Tag) updateTagKey(key string, value string) error {
 	return nil
 }
 
+func unquote(value string) string {
+	if len(value) > 1 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
 func (t *Tag) getColumnName(field reflect.StructField) string {
 	columnName := ""
 	if name := t.Name(); name != "" {
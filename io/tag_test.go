@@ -0,0 +1,25 @@
+package io
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestParseTag_Tenant(t *testing.T) {
+	var testCases = []struct {
+		description  string
+		tag          reflect.StructTag
+		expectColumn string
+		expectTenant bool
+	}{
+		{description: "bare tenant flag", tag: `sqlx:"tenant"`, expectTenant: true},
+		{description: "tenant flag with column", tag: `sqlx:"tenant_id,tenant"`, expectColumn: "tenant_id", expectTenant: true},
+		{description: "tenant column name", tag: `sqlx:"name=tenant"`, expectColumn: "tenant"},
+	}
+	for _, testCase := range testCases {
+		tag := ParseTag(testCase.tag)
+		assert.EqualValues(t, testCase.expectColumn, tag.Column, testCase.description)
+		assert.EqualValues(t, testCase.expectTenant, tag.Tenant, testCase.description)
+	}
+}
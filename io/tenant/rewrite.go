@@ -0,0 +1,121 @@
+package tenant
+
+import (
+	"fmt"
+	"strings"
+)
+
+type token struct {
+	word  string
+	pos   int
+	depth int
+}
+
+// clauses represents keywords ending WHERE clause
+var clauses = map[string]bool{"GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true, "OFFSET": true, "FETCH": true, "FOR": true, "WINDOW": true, "QUALIFY": true}
+
+// Rewrite adds tenant column predicate to simple SELECT query (single SELECT without subqueries and set operators) with '?' placeholders,
+// it returns rewritten query and tenant argument position
+func Rewrite(SQL string, column string) (string, int, error) {
+	SQL = strings.TrimRight(strings.TrimSpace(SQL), ";")
+	tokens, placeholders := scan(SQL)
+	selects := 0
+	for _, item := range tokens {
+		switch item.word {
+		case "SELECT":
+			selects++
+		case "UNION", "INTERSECT", "EXCEPT":
+			selects = -1
+		}
+		if selects < 0 {
+			break
+		}
+	}
+	if selects != 1 || len(tokens) == 0 || tokens[0].word != "SELECT" {
+		return "", 0, fmt.Errorf("unsupported tenant query, expected single SELECT statement: %v", SQL)
+	}
+	where, end := -1, len(SQL)
+	for _, item := range tokens {
+		if item.depth != 0 {
+			continue
+		}
+		if item.word == "WHERE" && where == -1 {
+			where = item.pos
+			continue
+		}
+		if clauses[item.word] {
+			end = item.pos
+			break
+		}
+	}
+	argIndex := 0
+	for _, pos := range placeholders {
+		if pos < end {
+			argIndex++
+		}
+	}
+	predicate := column + " = ?"
+	builder := strings.Builder{}
+	if where != -1 {
+		criteria := where + len("WHERE")
+		builder.WriteString(SQL[:criteria])
+		builder.WriteString(" (")
+		builder.WriteString(strings.TrimSpace(SQL[criteria:end]))
+		builder.WriteString(") AND ")
+	} else {
+		builder.WriteString(strings.TrimSpace(SQL[:end]))
+		builder.WriteString(" WHERE ")
+	}
+	builder.WriteString(predicate)
+	if end < len(SQL) {
+		builder.WriteString(" ")
+		builder.WriteString(SQL[end:])
+	}
+	return builder.String(), argIndex, nil
+}
+
+// scan returns upper case words and placeholder positions outside of quotes and comments
+func scan(SQL string) ([]token, []int) {
+	var tokens []token
+	var placeholders []int
+	depth := 0
+	for i := 0; i < len(SQL); i++ {
+		switch c := SQL[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			if index := strings.IndexByte(SQL[i+1:], c); index != -1 {
+				i += index + 1
+			} else {
+				i = len(SQL)
+			}
+		case c == '-' && strings.HasPrefix(SQL[i:], "--"):
+			if index := strings.IndexByte(SQL[i:], '\n'); index != -1 {
+				i += index
+			} else {
+				i = len(SQL)
+			}
+		case c == '/' && strings.HasPrefix(SQL[i:], "/*"):
+			if index := strings.Index(SQL[i:], "*/"); index != -1 {
+				i += index + 1
+			} else {
+				i = len(SQL)
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '?':
+			placeholders = append(placeholders, i)
+		case isWordChar(c):
+			start := i
+			for i+1 < len(SQL) && isWordChar(SQL[i+1]) {
+				i++
+			}
+			tokens = append(tokens, token{word: strings.ToUpper(SQL[start : i+1]), pos: start, depth: depth})
+		}
+	}
+	return tokens, placeholders
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '.' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
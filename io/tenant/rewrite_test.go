@@ -0,0 +1,61 @@
+package tenant
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      string
+		expectArg   int
+		expectErr   bool
+	}{
+		{
+			description: "no where clause",
+			SQL:         "SELECT * FROM orders",
+			expect:      "SELECT * FROM orders WHERE tenant_id = ?",
+		},
+		{
+			description: "existing criteria",
+			SQL:         "SELECT * FROM orders WHERE status = ? OR id = ? ORDER BY id LIMIT ?",
+			expect:      "SELECT * FROM orders WHERE (status = ? OR id = ?) AND tenant_id = ? ORDER BY id LIMIT ?",
+			expectArg:   2,
+		},
+		{
+			description: "group by without where",
+			SQL:         "SELECT status, COUNT(*) FROM orders GROUP BY status;",
+			expect:      "SELECT status, COUNT(*) FROM orders WHERE tenant_id = ? GROUP BY status",
+		},
+		{
+			description: "quoted keywords and placeholders",
+			SQL:         "SELECT * FROM orders WHERE note = 'where ? order' AND id = ?",
+			expect:      "SELECT * FROM orders WHERE (note = 'where ? order' AND id = ?) AND tenant_id = ?",
+			expectArg:   1,
+		},
+		{
+			description: "subquery",
+			SQL:         "SELECT * FROM orders WHERE id IN (SELECT order_id FROM items)",
+			expectErr:   true,
+		},
+		{
+			description: "union",
+			SQL:         "SELECT id FROM orders UNION ALL SELECT id FROM archive",
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		actual, argIndex, err := Rewrite(testCase.SQL, "tenant_id")
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectArg, argIndex, testCase.description)
+	}
+}
@@ -0,0 +1,31 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMissingID represents missing context tenant ID error
+var ErrMissingID = errors.New("tenant ID was missing in context")
+
+type idKey struct{}
+
+// WithID returns context carrying tenant ID, services set and filter `sqlx:"tenant"` tagged column with it
+func WithID(ctx context.Context, ID interface{}) context.Context {
+	return context.WithValue(ctx, idKey{}, ID)
+}
+
+// ID returns context tenant ID
+func ID(ctx context.Context) (interface{}, bool) {
+	ID := ctx.Value(idKey{})
+	return ID, ID != nil
+}
+
+// RequiredID returns context tenant ID or ErrMissingID
+func RequiredID(ctx context.Context) (interface{}, error) {
+	ID, ok := ID(ctx)
+	if !ok {
+		return nil, ErrMissingID
+	}
+	return ID, nil
+}
@@ -0,0 +1,118 @@
+package tenant_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/delete"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/tenant"
+	"github.com/viant/sqlx/io/update"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"path"
+	"testing"
+)
+
+type order struct {
+	ID       int    `sqlx:"id,primaryKey"`
+	TenantID string `sqlx:"tenant_id,tenant"`
+	Status   string `sqlx:"status"`
+}
+
+func TestTenant(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "tenant.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE orders (id INTEGER, tenant_id TEXT, status TEXT, PRIMARY KEY(id, tenant_id))")
+	if !assert.Nil(t, err) {
+		return
+	}
+	tenantA := tenant.WithID(context.Background(), "a")
+	tenantB := tenant.WithID(context.Background(), "b")
+
+	inserter, err := insert.New(tenantA, db, "orders")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = inserter.Exec(context.Background(), []*order{{ID: 1}})
+	assert.ErrorIs(t, err, tenant.ErrMissingID, "insert without tenant")
+	orders := []*order{{ID: 1, TenantID: "b", Status: "new"}, {ID: 2, Status: "new"}}
+	_, _, err = inserter.Exec(tenantA, orders)
+	assert.Nil(t, err, "insert tenant a")
+	for _, item := range orders {
+		assert.EqualValues(t, "a", item.TenantID, "insert assigns tenant ID to record")
+	}
+	_, _, err = inserter.Exec(tenantB, []*order{{ID: 1, Status: "new"}})
+	assert.Nil(t, err, "insert tenant b")
+
+	updater, err := update.New(tenantB, db, "orders")
+	if !assert.Nil(t, err) {
+		return
+	}
+	affected, err := updater.Exec(tenantB, []*order{{ID: 2, Status: "hijacked"}, {ID: 1, Status: "paid"}})
+	assert.Nil(t, err, "update tenant b")
+	assert.EqualValues(t, 1, affected, "update tenant b")
+
+	deleter, err := delete.New(tenantB, db, "orders")
+	if !assert.Nil(t, err) {
+		return
+	}
+	affected, err = deleter.Exec(tenantB, []*order{{ID: 2}})
+	assert.Nil(t, err, "delete tenant b")
+	assert.EqualValues(t, 0, affected, "delete tenant b")
+
+	var testCases = []struct {
+		description string
+		ctx         context.Context
+		expect      map[int]string
+	}{
+		{description: "tenant a rows", ctx: tenantA, expect: map[int]string{1: "new", 2: "new"}},
+		{description: "tenant b rows", ctx: tenantB, expect: map[int]string{1: "paid"}},
+	}
+	for _, testCase := range testCases {
+		reader, err := read.New(testCase.ctx, db, "SELECT id, tenant_id, status FROM orders WHERE id > ? ORDER BY id", func() interface{} { return &order{} }, read.WithTenant("tenant_id"))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual = map[int]string{}
+		err = reader.QueryAll(testCase.ctx, func(row interface{}) error {
+			anOrder := row.(*order)
+			actual[anOrder.ID] = anOrder.Status
+			return nil
+		}, 0)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestTenant_BareTag(t *testing.T) {
+	type account struct {
+		ID       int    `sqlx:"id,primaryKey"`
+		TenantID string `sqlx:"tenant"`
+	}
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "tenant_bare.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE accounts (id INTEGER PRIMARY KEY, TenantID TEXT)")
+	if !assert.Nil(t, err) {
+		return
+	}
+	ctx := tenant.WithID(context.Background(), "a")
+	inserter, err := insert.New(ctx, db, "accounts")
+	if !assert.Nil(t, err) {
+		return
+	}
+	accounts := []*account{{ID: 1}}
+	_, _, err = inserter.Exec(ctx, accounts)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "a", accounts[0].TenantID)
+	var tenantID string
+	assert.Nil(t, db.QueryRow("SELECT TenantID FROM accounts WHERE id = 1").Scan(&tenantID))
+	assert.EqualValues(t, "a", tenantID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx/io/hook"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 )
@@ -11,6 +12,8 @@ import (
 type Transaction struct {
 	*sql.Tx
 	Global bool
+	hooks  *hook.Chain
+	ctx    context.Context
 }
 
 func TransactionFor(ctx context.Context, dialect *info.Dialect, db *sql.DB, options []option.Option) (*Transaction, error) {
@@ -27,18 +30,23 @@ func TransactionFor(ctx context.Context, dialect *info.Dialect, db *sql.DB, opti
 		}, nil
 	}
 
-	tx, err := db.BeginTx(ctx, nil)
+	hooks := option.Options(options).Hooks()
+	beginCtx, done := hooks.Start(ctx, &hook.Event{Op: hook.OpBegin})
+	tx, err := db.BeginTx(beginCtx, nil)
+	done(0, err)
 	if err != nil {
 		if tx == nil {
 			return nil, err
 		}
 
-		return nil, (&Transaction{Tx: tx}).RollbackWithErr(err)
+		return nil, (&Transaction{Tx: tx, hooks: hooks, ctx: ctx}).RollbackWithErr(err)
 	}
 
 	return &Transaction{
 		Tx:     tx,
 		Global: false,
+		hooks:  hooks,
+		ctx:    ctx,
 	}, nil
 }
 
@@ -47,7 +55,7 @@ func (t *Transaction) Rollback() error {
 		return nil
 	}
 
-	return t.Tx.Rollback()
+	return t.rollback()
 }
 
 func (t *Transaction) RollbackWithErr(err error) error {
@@ -55,7 +63,7 @@ func (t *Transaction) RollbackWithErr(err error) error {
 		return err
 	}
 
-	if trErr := t.Tx.Rollback(); trErr != nil {
+	if trErr := t.rollback(); trErr != nil {
 		return fmt.Errorf("failed to rollback: %w, %v", err, trErr)
 	}
 
@@ -66,5 +74,22 @@ func (t *Transaction) Commit() error {
 	if t.Global {
 		return nil
 	}
-	return t.Tx.Commit()
+	_, done := t.hooks.Start(t.context(), &hook.Event{Op: hook.OpCommit})
+	err := t.Tx.Commit()
+	done(0, err)
+	return err
+}
+
+func (t *Transaction) rollback() error {
+	_, done := t.hooks.Start(t.context(), &hook.Event{Op: hook.OpRollback})
+	err := t.Tx.Rollback()
+	done(0, err)
+	return err
+}
+
+func (t *Transaction) context() context.Context {
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
 }
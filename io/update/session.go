@@ -5,9 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/audit"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/io/errx"
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/tenant"
 	"github.com/viant/sqlx/option"
+	"github.com/viant/xunsafe"
 	"reflect"
 )
 
@@ -21,6 +25,9 @@ type session struct {
 	identityIndex int
 	db            *sql.DB
 	stmt          *sql.Stmt
+	SQL           string
+	hooks         *hook.Chain
+	recorder      *audit.Recorder
 }
 
 func (s *session) init(record interface{}, options ...option.Option) (err error) {
@@ -50,11 +57,19 @@ func (s *session) init(record interface{}, options ...option.Option) (err error)
 
 func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
 	var err error
+	s.hooks = sqlHooks(options)
 	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
 	if err != nil {
 		return err
 	}
-
+	var trail *audit.Trail
+	if option.Assign(options, &trail) {
+		var auditDB audit.DB = db
+		if s.Transaction != nil {
+			auditDB = s.Transaction.Tx
+		}
+		s.recorder = trail.Recorder(s.TableName, s.columns[s.identityIndex:].Names(), s.Dialect, auditDB)
+	}
 	return nil
 }
 
@@ -73,37 +88,72 @@ func (s *session) prepare(ctx context.Context, record interface{}, dml *string)
 			return false, fmt.Errorf("failed to close stetement: %w", err)
 		}
 	}
-	if showSQL {
-		fmt.Println(SQL)
-	}
+	s.SQL = SQL
+	ctx, done := s.hooks.Start(ctx, s.event(hook.OpPrepare, nil))
 	if s.Transaction != nil {
 		s.stmt, err = s.Transaction.Prepare(SQL)
-		return err == nil, err
+	} else {
+		s.stmt, err = s.db.PrepareContext(ctx, SQL)
 	}
-	s.stmt, err = s.db.PrepareContext(ctx, SQL)
+	done(0, err)
 	return err == nil, err
 }
 
+func (s *session) event(op hook.Operation, args []interface{}) *hook.Event {
+	return &hook.Event{Op: op, Source: "update", Table: s.TableName, SQL: s.SQL, Args: args}
+}
+
 func (s *session) update(ctx context.Context, record interface{}) (int64, error) {
 
 	var placeholders = make([]interface{}, len(s.columns))
 	s.binder(record, placeholders, 0, len(s.columns))
+	if tenantIndex := s.columns.Tenant(); tenantIndex != -1 {
+		tenantID, err := tenant.RequiredID(ctx)
+		if err != nil {
+			return 0, err
+		}
+		placeholders[tenantIndex] = tenantID
+	}
+	key := placeholders[s.identityIndex:]
+	before, err := s.recorder.Fetch(ctx, key)
+	if err != nil {
+		return 0, err
+	}
 
 	placeholders = s.setMarker.Placeholders(record, placeholders)
+	ctx, done := s.hooks.Start(ctx, s.event(hook.OpExec, placeholders))
 	result, err := s.stmt.ExecContext(ctx, placeholders...)
 	if err != nil {
-		if errx.IsDuplicateKey(err) {
-			return 0, errx.DuplicateKey("update", s.TableName, err)
-		}
-		if errx.IsConstraint(err) {
-			return 0, errx.Constraint("update", s.TableName, err)
-		}
-		return 0, err
+		done(0, err)
+		return 0, errx.Wrap("update", s.TableName, err)
 	}
 	affected, _ := result.RowsAffected()
+	done(affected, nil)
+	if before != nil && affected > 0 {
+		after, err := s.recorder.Fetch(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		s.recorder.Add(ctx, audit.OpUpdate, key, before, after, s.changedColumns(record))
+	}
 	return affected, nil
 }
 
+// changedColumns returns set marker columns or nil if record has no set marker
+func (s *session) changedColumns(record interface{}) []string {
+	if s.setMarker.Marker == nil {
+		return nil
+	}
+	var result = make([]string, 0, s.setMarker.IdentityIndex)
+	ptr := xunsafe.AsPointer(record)
+	for i := 0; i < s.setMarker.IdentityIndex; i++ {
+		if s.setMarker.IsSet(ptr, i) {
+			result = append(result, s.columns[i].Name())
+		}
+	}
+	return result
+}
+
 func (s *session) end(err error) error {
 	if s.stmt != nil {
 		if sErr := s.stmt.Close(); sErr != nil {
@@ -0,0 +1,42 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/option"
+	"sync"
+)
+
+// execShards splits records by shard and updates shard groups in parallel
+func (s *Service) execShards(ctx context.Context, resolver option.ShardResolver, any interface{}, options ...option.Option) (int64, error) {
+	if option.Options(options).Tx() != nil {
+		return 0, fmt.Errorf("shard resolver can not be used with global transaction")
+	}
+	groups, err := io.GroupByShard(any, resolver)
+	if err != nil {
+		return 0, err
+	}
+	shardOptions := option.Options(options).Unsharded()
+	var rowsAffected int64
+	var mux sync.Mutex
+	err = io.RunShards(groups, func(group *io.ShardGroup) error {
+		affected, err := s.shardService(group.Shard).Exec(ctx, group.Records, shardOptions...)
+		mux.Lock()
+		rowsAffected += affected
+		mux.Unlock()
+		return err
+	})
+	return rowsAffected, err
+}
+
+func (s *Service) shardService(shard option.Shard) *Service {
+	if cached, ok := s.shards.Load(shard); ok {
+		return cached.(*Service)
+	}
+	aConfig := *s.Config
+	aConfig.TableName += shard.Suffix
+	aConfig.Builder = nil
+	actual, _ := s.shards.LoadOrStore(shard, &Service{Config: &aConfig, db: shard.DB})
+	return actual.(*Service)
+}
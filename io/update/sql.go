@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/viant/sqlx/io/errx"
+	"github.com/viant/sqlx/io/hook"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 	"github.com/viant/xunsafe"
+	"os"
 	"strings"
 )
 
@@ -78,6 +80,19 @@ func NewBuilder(table string, columns []string, identityIndex int, dialect *info
 
 var showSQL bool
 
+// ShowSQL prints prepared SQL to stdout when no hooks were supplied with options
+//
+// Deprecated: use hook.NewPrinter(os.Stdout) or hook.NewSlog(logger, level) option instead
 func ShowSQL(b bool) {
 	showSQL = b
 }
+
+func sqlHooks(options []option.Option) *hook.Chain {
+	if hooks := option.Options(options).Hooks(); hooks != nil {
+		return hooks
+	}
+	if showSQL {
+		return hook.New(hook.NewPrinter(os.Stdout))
+	}
+	return nil
+}
@@ -9,9 +9,18 @@ import (
 )
 
 const (
-	CheckKidUnique  = CheckKid("unique")
-	CheckKidRefKey  = CheckKid("refKey")
-	CheckKidNotNull = CheckKid("notnull")
+	CheckKidUnique     = CheckKid("unique")
+	CheckKidRefKey     = CheckKid("refKey")
+	CheckKidNotNull    = CheckKid("notnull")
+	CheckKidLength     = CheckKid("length")
+	CheckKidPrecision  = CheckKid("precision")
+	CheckKidEnum       = CheckKid("enum")
+	CheckKidMin        = CheckKid("min")
+	CheckKidMax        = CheckKid("max")
+	CheckKidPattern    = CheckKid("pattern")
+	CheckKidOneOf      = CheckKid("oneOf")
+	CheckKidCompare    = CheckKid("compare")
+	CheckKidConstraint = CheckKid("check")
 )
 
 type (
@@ -28,11 +37,16 @@ type (
 	}
 
 	Checks struct {
-		Type     reflect.Type
-		Unique   []*Check
-		RefKey   []*Check
-		NoNull   []*Check
-		presence *option.SetMarker
+		Type   reflect.Type
+		Unique []*Check
+		RefKey []*Check
+		NoNull []*Check
+		// CompositeUnique and CompositeRef group columns sharing uniqueKey or refKey tag name
+		CompositeUnique []*CompositeCheck
+		CompositeRef    []*CompositeCheck
+		Duplicates      []*DuplicateCheck
+		Values          []*ValueCheck
+		presence        *option.SetMarker
 	}
 )
 
@@ -78,6 +92,25 @@ func NewChecks(p reflect.Type, presence *option.SetMarker) (*Checks, error) {
 
 		xField := fields[len(fields)-1]
 
+		valueCheck, err := NewValueCheck(xField, tag, func(name string) *xunsafe.Field {
+			if field := xunsafe.FieldByName(sType, name); field != nil {
+				return field
+			}
+			if column, ok := columnByName[name]; ok {
+				if fielder, ok := column.(io.Fielder); ok {
+					columnFields := fielder.Fields()
+					return columnFields[len(columnFields)-1]
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if valueCheck != nil {
+			result.Values = append(result.Values, valueCheck)
+		}
+
 		if tag.Required {
 			result.NoNull = append(result.NoNull, &Check{
 				Field:    xField,
@@ -110,7 +143,7 @@ func NewChecks(p reflect.Type, presence *option.SetMarker) (*Checks, error) {
 			continue
 		}
 
-		if tag.RefColumn != "" && tag.RefTable != "" {
+		if tag.RefColumn != "" && tag.RefTable != "" && tag.RefKey == "" {
 			checkType := reflect.StructOf([]reflect.StructField{{Name: xField.Name, Type: xField.Type, Tag: `sqlx:"Val"`}})
 			checkField := xunsafe.NewField(checkType.Field(0))
 			result.RefKey = append(result.RefKey, &Check{
@@ -122,6 +155,10 @@ func NewChecks(p reflect.Type, presence *option.SetMarker) (*Checks, error) {
 			})
 		}
 	}
+	if result.CompositeUnique, result.CompositeRef, err = newCompositeChecks(columns, identityColumn, p); err != nil {
+		return nil, err
+	}
+	result.Duplicates = newDuplicateChecks(columns, result)
 	return result, nil
 }
 
@@ -0,0 +1,331 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// CompositeCheck represents multi columns unique or reference key check,
+	// key columns are defined with the same uniqueKey or refKey tag name, i.e.
+	// `sqlx:"dept_id,uniqueKey=uk_name,table=emp"` and `sqlx:"name,uniqueKey=uk_name"`
+	CompositeCheck struct {
+		Name           string
+		Table          string
+		Columns        []string
+		Fields         []*xunsafe.Field
+		ErrorMsg       string
+		CheckType      reflect.Type
+		CheckFields    []*xunsafe.Field
+		IdentityColumn *io.Column
+	}
+
+	compositeMatch struct {
+		key    string
+		values []interface{}
+		path   *Path
+	}
+)
+
+// FieldNames returns key fields names
+func (c *CompositeCheck) FieldNames() string {
+	var names = make([]string, len(c.Fields))
+	for i, field := range c.Fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func (c *CompositeCheck) init() {
+	var fields = make([]reflect.StructField, len(c.Fields))
+	for i, field := range c.Fields {
+		fields[i] = reflect.StructField{Name: "Val" + strconv.Itoa(i), Type: field.Type, Tag: reflect.StructTag(`sqlx:"Val` + strconv.Itoa(i) + `"`)}
+	}
+	c.CheckType = reflect.StructOf(fields)
+	c.CheckFields = make([]*xunsafe.Field, len(fields))
+	for i := range fields {
+		c.CheckFields[i] = xunsafe.NewField(c.CheckType.Field(i))
+	}
+}
+
+// SQL returns check query matching all supplied keys with one statement
+func (c *CompositeCheck) SQL(dialect *info.Dialect, keys int, exclusions int) string {
+	sb := strings.Builder{}
+	sb.WriteString("SELECT ")
+	for i, column := range c.Columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column)
+		sb.WriteString(" AS Val")
+		sb.WriteString(strconv.Itoa(i))
+	}
+	sb.WriteString(" FROM ")
+	sb.WriteString(c.Table)
+	sb.WriteString(" WHERE ")
+	if dialect != nil && dialect.CanRowValueIn {
+		sb.WriteString("(")
+		sb.WriteString(strings.Join(c.Columns, ", "))
+		sb.WriteString(") IN (")
+		tuple := "(" + strings.Repeat("?, ", len(c.Columns)-1) + "?)"
+		for i := 0; i < keys; i++ {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(tuple)
+		}
+		sb.WriteString(")")
+	} else {
+		sb.WriteString("(")
+		for i := 0; i < keys; i++ {
+			if i > 0 {
+				sb.WriteString(" OR ")
+			}
+			sb.WriteString("(")
+			for j, column := range c.Columns {
+				if j > 0 {
+					sb.WriteString(" AND ")
+				}
+				sb.WriteString(column)
+				sb.WriteString(" = ?")
+			}
+			sb.WriteString(")")
+		}
+		sb.WriteString(")")
+	}
+	if exclusions > 0 {
+		sb.WriteString(" AND ")
+		sb.WriteString((*c.IdentityColumn).Name())
+		sb.WriteString(" NOT IN (")
+		sb.WriteString(strings.Repeat("?, ", exclusions-1))
+		sb.WriteString("?)")
+	}
+	SQL := sb.String()
+	if dialect != nil {
+		SQL = dialect.EnsurePlaceholders(SQL)
+	}
+	return SQL
+}
+
+func newCompositeChecks(columns []io.Column, identityColumn io.Column, p reflect.Type) (unique []*CompositeCheck, refs []*CompositeCheck, err error) {
+	var uniqueByName = map[string]*CompositeCheck{}
+	var refByName = map[string]*CompositeCheck{}
+	for _, column := range columns {
+		tag := column.Tag()
+		if tag == nil || (tag.UniqueKey == "" && tag.RefKey == "") {
+			continue
+		}
+		fielder, ok := column.(io.Fielder)
+		if !ok {
+			continue
+		}
+		fields := fielder.Fields()
+		xField := fields[len(fields)-1]
+
+		if tag.UniqueKey != "" {
+			check, ok := uniqueByName[tag.UniqueKey]
+			if !ok {
+				check = &CompositeCheck{Name: tag.UniqueKey}
+				if identityColumn != nil {
+					check.IdentityColumn = &identityColumn
+				}
+				uniqueByName[tag.UniqueKey] = check
+				unique = append(unique, check)
+			}
+			if tag.Table != "" {
+				check.Table = qualify(tag.Db, tag.Table)
+			}
+			if tag.ErrorMgs != "" {
+				check.ErrorMsg = tag.ErrorMgs
+			}
+			check.Columns = append(check.Columns, column.Name())
+			check.Fields = append(check.Fields, xField)
+		}
+
+		if tag.RefKey != "" {
+			check, ok := refByName[tag.RefKey]
+			if !ok {
+				check = &CompositeCheck{Name: tag.RefKey}
+				refByName[tag.RefKey] = check
+				refs = append(refs, check)
+			}
+			if tag.RefTable != "" {
+				check.Table = qualify(tag.RefDb, tag.RefTable)
+			}
+			if tag.ErrorMgs != "" {
+				check.ErrorMsg = tag.ErrorMgs
+			}
+			refColumn := tag.RefColumn
+			if refColumn == "" {
+				refColumn = column.Name()
+			}
+			check.Columns = append(check.Columns, refColumn)
+			check.Fields = append(check.Fields, xField)
+		}
+	}
+
+	for _, check := range append(append([]*CompositeCheck{}, unique...), refs...) {
+		if check.Table == "" {
+			return nil, nil, fmt.Errorf("composite key %v table was not defined in type: %s", check.Name, p.String())
+		}
+		check.init()
+	}
+	return unique, refs, nil
+}
+
+func (s *Service) dialect(ctx context.Context, db *sql.DB) (*info.Dialect, error) {
+	s.mux.RLock()
+	dialect, ok := s.dialects[db]
+	s.mux.RUnlock()
+	if ok {
+		return dialect, nil
+	}
+	dialect, err := config.Dialect(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	s.mux.Lock()
+	s.dialects[db] = dialect
+	s.mux.Unlock()
+	return dialect, nil
+}
+
+func (s *Service) checkCompositeUniques(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, checks []*CompositeCheck, violations *Validation, options *Options) error {
+	if len(checks) == 0 || !options.CheckUnique {
+		return nil
+	}
+	for _, check := range checks {
+		matches, exclusions := s.compositeMatches(check, path, at, count, options, check.IdentityColumn != nil)
+		if len(matches) == 0 {
+			continue
+		}
+		found, err := s.queryComposite(ctx, db, check, matches, exclusions)
+		if err != nil {
+			return err
+		}
+		for _, match := range matches {
+			if found[match.key] {
+				violations.AppendUnique(match.path, check.FieldNames(), formatValues(match.values), check.ErrorMsg)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) checkCompositeRefs(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, checks []*CompositeCheck, violations *Validation, options *Options) error {
+	if len(checks) == 0 || !options.CheckRef {
+		return nil
+	}
+	for _, check := range checks {
+		matches, _ := s.compositeMatches(check, path, at, count, options, false)
+		if len(matches) == 0 {
+			continue
+		}
+		found, err := s.queryComposite(ctx, db, check, matches, nil)
+		if err != nil {
+			return err
+		}
+		for _, match := range matches {
+			if !found[match.key] {
+				violations.AppendRef(match.path, check.FieldNames(), formatValues(match.values), check.ErrorMsg)
+			}
+		}
+	}
+	return nil
+}
+
+// compositeMatches returns records keys, keys with any NULL value are skipped
+func (s *Service) compositeMatches(check *CompositeCheck, path *Path, at io.ValueAccessor, count int, options *Options, withExclusions bool) ([]*compositeMatch, []interface{}) {
+	var matches []*compositeMatch
+	var exclusions []interface{}
+	setMarker := options.SetMarker
+outer:
+	for i := 0; i < count; i++ {
+		recordPtr := xunsafe.AsPointer(at(i))
+		values := make([]interface{}, len(check.Fields))
+		for j, field := range check.Fields {
+			if setMarker != nil && setMarker.Marker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(field.Name))) {
+				continue outer
+			}
+			if values[j] = fieldValue(field, recordPtr); values[j] == nil {
+				continue outer
+			}
+		}
+		matches = append(matches, &compositeMatch{key: compositeKey(values), values: values, path: path.AppendIndex(i)})
+		if withExclusions {
+			fields := (*check.IdentityColumn).(io.Fielder).Fields()
+			if identity := fieldValue(fields[len(fields)-1], recordPtr); identity != nil {
+				exclusions = append(exclusions, identity)
+			}
+		}
+	}
+	return matches, exclusions
+}
+
+func (s *Service) queryComposite(ctx context.Context, db *sql.DB, check *CompositeCheck, matches []*compositeMatch, exclusions []interface{}) (map[string]bool, error) {
+	dialect, err := s.dialect(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var args = make([]interface{}, 0, len(matches)*len(check.Fields)+len(exclusions))
+	for _, match := range matches {
+		args = append(args, match.values...)
+	}
+	args = append(args, exclusions...)
+
+	reader, err := read.New(ctx, db, check.SQL(dialect, len(matches), len(exclusions)), func() interface{} {
+		return reflect.New(check.CheckType).Interface()
+	})
+	if err != nil {
+		return nil, err
+	}
+	var found = map[string]bool{}
+	err = reader.QueryAll(ctx, func(record interface{}) error {
+		recordPtr := xunsafe.AsPointer(record)
+		values := make([]interface{}, len(check.CheckFields))
+		for i, field := range check.CheckFields {
+			values[i] = fieldValue(field, recordPtr)
+		}
+		found[compositeKey(values)] = true
+		return nil
+	}, args...)
+	if stmt := reader.Stmt(); stmt != nil {
+		_ = stmt.Close()
+	}
+	return found, err
+}
+
+func qualify(db, table string) string {
+	if db == "" {
+		return table
+	}
+	return db + "." + table
+}
+
+func compositeKey(values []interface{}) string {
+	sb := strings.Builder{}
+	for i, value := range values {
+		if i > 0 {
+			sb.WriteByte(0)
+		}
+		sb.WriteString(fmt.Sprintf("%v", value))
+	}
+	return sb.String()
+}
+
+func formatValues(values []interface{}) string {
+	var texts = make([]string, len(values))
+	for i, value := range values {
+		texts[i] = fmt.Sprintf("%v", value)
+	}
+	return strings.Join(texts, ",")
+}
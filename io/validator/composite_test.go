@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/info"
+	"path"
+	"reflect"
+	"testing"
+)
+
+type CompositeRecord struct {
+	Id     int    `sqlx:"id,primaryKey"`
+	DeptId int    `sqlx:"dept_id,uniqueKey=uk_emp,table=emp"`
+	Code   string `sqlx:"code,uniqueKey=uk_emp"`
+}
+
+type CompositeRefRecord struct {
+	Id     int     `sqlx:"id,primaryKey"`
+	DeptId *int    `sqlx:"dept_id,refKey=fk_dept,refTable=dept,refColumn=id"`
+	Region *string `sqlx:"region,refKey=fk_dept,refColumn=region"`
+}
+
+func TestCompositeCheck_SQL(t *testing.T) {
+	checks, err := NewChecks(reflect.TypeOf(&CompositeRecord{}), nil)
+	if !assert.Nil(t, err) || !assert.Len(t, checks.CompositeUnique, 1) {
+		return
+	}
+	check := checks.CompositeUnique[0]
+	var testCases = []struct {
+		description string
+		dialect     *info.Dialect
+		expect      string
+	}{
+		{
+			description: "row value in",
+			dialect:     &info.Dialect{Placeholder: "?", CanRowValueIn: true},
+			expect:      "SELECT dept_id AS Val0, code AS Val1 FROM emp WHERE (dept_id, code) IN ((?, ?), (?, ?)) AND id NOT IN (?, ?)",
+		},
+		{
+			description: "or fallback",
+			dialect:     &info.Dialect{Placeholder: "?"},
+			expect:      "SELECT dept_id AS Val0, code AS Val1 FROM emp WHERE ((dept_id = ? AND code = ?) OR (dept_id = ? AND code = ?)) AND id NOT IN (?, ?)",
+		},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, check.SQL(testCase.dialect, 2, 2), testCase.description)
+	}
+}
+
+func TestService_Validate_Composite(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "composite.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	for _, DDL := range []string{
+		"CREATE TABLE emp (id INTEGER PRIMARY KEY, dept_id INTEGER, code TEXT)",
+		"INSERT INTO emp VALUES (1, 10, 'A'), (2, 20, 'B')",
+		"CREATE TABLE dept (id INTEGER, region TEXT)",
+		"INSERT INTO dept VALUES (10, 'us'), (20, 'eu')",
+	} {
+		if _, err = db.Exec(DDL); !assert.Nil(t, err, DDL) {
+			return
+		}
+	}
+	var testCases = []struct {
+		description string
+		data        interface{}
+		expected    []*Violation
+	}{
+		{
+			description: "unique composite",
+			data: []*CompositeRecord{
+				{Id: 3, DeptId: 10, Code: "A"},
+				{Id: 4, DeptId: 10, Code: "B"},
+				{Id: 2, DeptId: 20, Code: "B"},
+			},
+			expected: []*Violation{
+				{Location: "emp[0]", Field: "DeptId,Code", Value: "10,A", Message: "value '10,A' is not unique", Check: "unique"},
+			},
+		},
+		{
+			description: "ref composite",
+			data: []*CompositeRefRecord{
+				{Id: 1, DeptId: intPtr(10), Region: stringPtr("us")},
+				{Id: 2, DeptId: intPtr(10), Region: stringPtr("eu")},
+				{Id: 3, DeptId: intPtr(30)},
+			},
+			expected: []*Violation{
+				{Location: "emp[1]", Field: "DeptId,Region", Value: "10,eu", Message: "ref key '10,eu' does not exists", Check: "refKey"},
+			},
+		},
+	}
+	srv := New()
+	for _, testCase := range testCases {
+		validation, err := srv.Validate(context.Background(), db, testCase.data, WithLocation("emp"))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expected, validation.Violations, testCase.description)
+	}
+}
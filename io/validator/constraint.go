@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"strings"
+)
+
+type (
+	// CheckConstraint represents table check constraint translated into in process predicate
+	CheckConstraint struct {
+		Name      string
+		Clause    string
+		Columns   []string
+		Fields    []*xunsafe.Field
+		Reason    string //reason why constraint can not be evaluated
+		predicate predicate
+	}
+
+	// UncheckedConstraint represents check constraint that could not be evaluated in process
+	UncheckedConstraint struct {
+		Name   string
+		Clause string
+		Reason string
+	}
+)
+
+// Evaluable returns true if constraint can be evaluated in process
+func (c *CheckConstraint) Evaluable() bool {
+	return c.predicate != nil
+}
+
+// NewCheckConstraints translates table check constraints for supplied record type
+func NewCheckConstraints(recordType reflect.Type, checks []sink.Check) ([]*CheckConstraint, error) {
+	structColumns, err := io.StructColumns(recordType)
+	if err != nil {
+		return nil, err
+	}
+	var fieldByColumn = make(map[string]*xunsafe.Field, len(structColumns))
+	for _, column := range structColumns {
+		if tag := column.Tag(); tag != nil && tag.Transient {
+			continue
+		}
+		if field := columnField(column); field != nil {
+			fieldByColumn[strings.ToLower(column.Name())] = field
+		}
+	}
+	var result = make([]*CheckConstraint, 0, len(checks))
+	for _, check := range checks {
+		constraint := &CheckConstraint{Name: check.Name, Clause: check.Clause}
+		result = append(result, constraint)
+		pred, columns, err := parseCheckExpr(check.Clause)
+		if err != nil {
+			constraint.Reason = err.Error()
+			continue
+		}
+		constraint.Columns = columns
+		for _, column := range columns {
+			field, ok := fieldByColumn[column]
+			if !ok {
+				constraint.Reason = fmt.Sprintf("column %v is not mapped by %v", column, recordType.String())
+				break
+			}
+			constraint.Fields = append(constraint.Fields, field)
+		}
+		if constraint.Reason == "" {
+			constraint.predicate = pred
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) checkConstraintsFor(ctx context.Context, db *sql.DB, recordType reflect.Type, options *Options) ([]*CheckConstraint, error) {
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	key := schemaKey{Type: recordType, Table: options.Table}
+	s.mux.RLock()
+	constraints, ok := s.constraints[key]
+	s.mux.RUnlock()
+	if ok && options.Constraints == nil {
+		return constraints, nil
+	}
+	checks := options.Constraints
+	if checks == nil {
+		var err error
+		if checks, err = s.tableCheckConstraints(ctx, db, options.Table); err != nil {
+			return nil, err
+		}
+	}
+	constraints, err := NewCheckConstraints(recordType, checks)
+	if err != nil {
+		return nil, err
+	}
+	if options.Constraints == nil {
+		s.mux.Lock()
+		s.constraints[key] = constraints
+		s.mux.Unlock()
+	}
+	return constraints, nil
+}
+
+func (s *Service) tableCheckConstraints(ctx context.Context, db *sql.DB, table string) ([]sink.Check, error) {
+	dialect, err := s.dialect(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	session, err := config.Session(ctx, db, dialect)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := config.CheckConstraints(ctx, session, db, table, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %v check constraints: %w", table, err)
+	}
+	return checks, nil
+}
+
+func (s *Service) checkConstraints(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, recordType reflect.Type, violations *Validation, options *Options) error {
+	if options.Table == "" || !options.CheckConstraints {
+		return nil
+	}
+	constraints, err := s.checkConstraintsFor(ctx, db, recordType, options)
+	if err != nil {
+		return err
+	}
+	setMarker := options.SetMarker
+	for _, constraint := range constraints {
+		if !constraint.Evaluable() {
+			violations.Unchecked = append(violations.Unchecked, &UncheckedConstraint{Name: constraint.Name, Clause: constraint.Clause, Reason: constraint.Reason})
+			continue
+		}
+	outer:
+		for i := 0; i < count; i++ {
+			recordPtr := xunsafe.AsPointer(at(i))
+			row := make(map[string]interface{}, len(constraint.Fields))
+			for j, field := range constraint.Fields {
+				if setMarker != nil && setMarker.Marker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(field.Name))) {
+					continue outer //constraint depends on value that is not being modified
+				}
+				row[constraint.Columns[j]] = fieldValue(field, recordPtr)
+			}
+			if constraint.predicate(row) != truthFalse {
+				continue //check constraint is satisfied when expression is true or unknown
+			}
+			itemPath := path.AppendIndex(i)
+			msg := fmt.Sprintf("check constraint %v violated: %v", constraint.Name, constraint.Clause)
+			if len(constraint.Fields) == 1 {
+				field := constraint.Fields[0]
+				violations.AppendCheck(itemPath.AppendField(field.Name), field.Name, row[constraint.Columns[0]], CheckKidConstraint, "", msg)
+				continue
+			}
+			names := make([]string, len(constraint.Fields))
+			for j, field := range constraint.Fields {
+				names[j] = field.Name
+			}
+			violations.AppendCheck(itemPath, strings.Join(names, ","), nil, CheckKidConstraint, "", msg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/sink"
+	"testing"
+)
+
+type ConstraintRecord struct {
+	Id     int      `sqlx:"id,primaryKey"`
+	Price  *float64 `sqlx:"price"`
+	Qty    int      `sqlx:"qty"`
+	MaxQty int      `sqlx:"max_qty"`
+	Status string   `sqlx:"status"`
+}
+
+func TestService_Validate_CheckConstraints(t *testing.T) {
+	columns := []sink.Column{
+		{Name: "id", Type: "int", Nullable: "NO", Key: "PRI"},
+		{Name: "price", Type: "numeric", Nullable: "YES"},
+	}
+	checks := []sink.Check{
+		{Name: "chk_price", Clause: "CHECK ((price > (0)::numeric))"},
+		{Name: "chk_qty", Clause: "CHECK ((qty <= max_qty))"},
+		{Name: "chk_status", Clause: "CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'inactive'::character varying])::text[])))"},
+		{Name: "chk_email", Clause: "CHECK ((email ~~ '%@%'::text))"},
+		{Name: "chk_region", Clause: "CHECK ((region <> ''::text))"},
+	}
+	var testCases = []struct {
+		description string
+		data        interface{}
+		expected    []*Violation
+	}{
+		{
+			description: "valid",
+			data:        &ConstraintRecord{Id: 1, Price: floatPtr(1), Qty: 1, MaxQty: 2, Status: "active"},
+		},
+		{
+			description: "null price is unknown",
+			data:        &ConstraintRecord{Id: 1, Qty: 1, MaxQty: 1, Status: "inactive"},
+		},
+		{
+			description: "violations",
+			data: []*ConstraintRecord{
+				{Id: 1, Price: floatPtr(0), Qty: 3, MaxQty: 2, Status: "active"},
+				{Id: 2, Price: floatPtr(1), Qty: 1, MaxQty: 2, Status: "deleted"},
+			},
+			expected: []*Violation{
+				{Location: "items[0]", Field: "Qty,MaxQty", Message: "check constraint chk_qty violated: CHECK ((qty <= max_qty))", Check: "check"},
+				{Location: "items[0].Price", Field: "Price", Value: 0.0, Message: "check constraint chk_price violated: CHECK ((price > (0)::numeric))", Check: "check"},
+				{Location: "items[1].Status", Field: "Status", Value: "deleted", Message: "check constraint chk_status violated: CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'inactive'::character varying])::text[])))", Check: "check"},
+			},
+		},
+	}
+	srv := New()
+	for _, testCase := range testCases {
+		validation, err := srv.Validate(context.Background(), nil, testCase.data, WithLocation("items"),
+			WithTableColumns("items", columns), WithTableCheckConstraints(checks))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(testCase.expected) > 0, validation.Failed, testCase.description)
+		assert.EqualValues(t, testCase.expected, validation.Violations, testCase.description)
+		if assert.Len(t, validation.Unchecked, 2, testCase.description) {
+			assert.EqualValues(t, "chk_email", validation.Unchecked[0].Name, testCase.description)
+			assert.EqualValues(t, "column region is not mapped by validator.ConstraintRecord", validation.Unchecked[1].Reason, testCase.description)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"strings"
+)
+
+type (
+	// DuplicateCheck represents in batch unique or primary key check
+	DuplicateCheck struct {
+		Fields   []*xunsafe.Field
+		ErrorMsg string
+		SkipZero bool //primary key zero value is assigned by database or generator
+	}
+
+	duplicateEntry struct {
+		path   *Path
+		values []interface{}
+	}
+)
+
+// FieldNames returns key fields names
+func (c *DuplicateCheck) FieldNames() string {
+	var names = make([]string, len(c.Fields))
+	for i, field := range c.Fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func newDuplicateChecks(columns []io.Column, checks *Checks) []*DuplicateCheck {
+	var result []*DuplicateCheck
+	var primaryKey = &DuplicateCheck{SkipZero: true}
+	for _, column := range columns {
+		if tag := column.Tag(); tag != nil && tag.PrimaryKey {
+			if field := columnField(column); field != nil {
+				primaryKey.Fields = append(primaryKey.Fields, field)
+			}
+		}
+	}
+	if len(primaryKey.Fields) > 0 {
+		result = append(result, primaryKey)
+	}
+	for _, check := range checks.Unique {
+		duplicate := &DuplicateCheck{Fields: []*xunsafe.Field{check.Field}, ErrorMsg: check.ErrorMsg}
+		if check.UniqueDep != nil {
+			if field := columnField(*check.UniqueDep); field != nil {
+				duplicate.Fields = append(duplicate.Fields, field)
+			}
+		}
+		result = append(result, duplicate)
+	}
+	for _, check := range checks.CompositeUnique {
+		result = append(result, &DuplicateCheck{Fields: check.Fields, ErrorMsg: check.ErrorMsg})
+	}
+	return result
+}
+
+func columnField(column io.Column) *xunsafe.Field {
+	fielder, ok := column.(io.Fielder)
+	if !ok {
+		return nil
+	}
+	fields := fielder.Fields()
+	return fields[len(fields)-1]
+}
+
+// checkDuplicates reports records sharing the same unique key value within validated batch, each duplicate is reported
+// with locations of the other records
+func (s *Service) checkDuplicates(path *Path, at io.ValueAccessor, count int, checks []*DuplicateCheck, violations *Validation, options *Options) {
+	if len(checks) == 0 || count < 2 || !options.CheckUnique {
+		return
+	}
+	if !path.IsSlice {
+		path = &Path{Elements: path.Elements, IsSlice: true}
+	}
+	setMarker := options.SetMarker
+	for _, check := range checks {
+		var keys []string
+		var entries = map[string][]*duplicateEntry{}
+	outer:
+		for i := 0; i < count; i++ {
+			recordPtr := xunsafe.AsPointer(at(i))
+			values := make([]interface{}, len(check.Fields))
+			for j, field := range check.Fields {
+				if setMarker != nil && setMarker.Marker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(field.Name))) {
+					continue outer
+				}
+				value := fieldValue(field, recordPtr)
+				if value == nil || (check.SkipZero && reflect.ValueOf(value).IsZero()) {
+					continue outer
+				}
+				values[j] = value
+			}
+			itemPath := path.AppendIndex(i)
+			if len(check.Fields) == 1 {
+				itemPath = itemPath.AppendField(check.Fields[0].Name)
+			}
+			key := compositeKey(values)
+			if _, ok := entries[key]; !ok {
+				keys = append(keys, key)
+			}
+			entries[key] = append(entries[key], &duplicateEntry{path: itemPath, values: values})
+		}
+		for _, key := range keys {
+			group := entries[key]
+			if len(group) < 2 {
+				continue
+			}
+			for _, entry := range group {
+				var others []string
+				for _, candidate := range group {
+					if candidate != entry {
+						others = append(others, candidate.path.String())
+					}
+				}
+				var value interface{} = formatValues(entry.values)
+				if len(entry.values) == 1 {
+					value = entry.values[0]
+				}
+				violations.AppendCheck(entry.path, check.FieldNames(), value, CheckKidUnique, check.ErrorMsg,
+					fmt.Sprintf("value '%v' is duplicated in batch at %v", value, strings.Join(others, ",")))
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"path"
+	"testing"
+)
+
+type DuplicateRecord struct {
+	Id    int     `sqlx:"id,primaryKey"`
+	Email *string `sqlx:"email,unique,table=users"`
+}
+
+func TestService_Validate_Duplicates(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "duplicate.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	for _, DDL := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT)",
+		"CREATE TABLE emp (id INTEGER PRIMARY KEY, dept_id INTEGER, code TEXT)",
+	} {
+		if _, err = db.Exec(DDL); !assert.Nil(t, err, DDL) {
+			return
+		}
+	}
+	var testCases = []struct {
+		description string
+		data        interface{}
+		options     []Option
+		expected    []*Violation
+	}{
+		{
+			description: "unique and primary key",
+			data: []*DuplicateRecord{
+				{Id: 1, Email: stringPtr("a@x.io")},
+				{Id: 2, Email: stringPtr("b@x.io")},
+				{Id: 1, Email: stringPtr("a@x.io")},
+				{Email: stringPtr("c@x.io")},
+				{Email: nil},
+				{Email: nil},
+			},
+			expected: []*Violation{
+				{Location: "users[0].Email", Field: "Email", Value: "a@x.io", Message: "value 'a@x.io' is duplicated in batch at users[2].Email", Check: "unique"},
+				{Location: "users[0].Id", Field: "Id", Value: 1, Message: "value '1' is duplicated in batch at users[2].Id", Check: "unique"},
+				{Location: "users[2].Email", Field: "Email", Value: "a@x.io", Message: "value 'a@x.io' is duplicated in batch at users[0].Email", Check: "unique"},
+				{Location: "users[2].Id", Field: "Id", Value: 1, Message: "value '1' is duplicated in batch at users[0].Id", Check: "unique"},
+			},
+		},
+		{
+			description: "composite unique",
+			data: []*CompositeRecord{
+				{Id: 1, DeptId: 10, Code: "A"},
+				{Id: 2, DeptId: 20, Code: "A"},
+				{Id: 3, DeptId: 10, Code: "A"},
+				{Id: 4, DeptId: 10, Code: "A"},
+			},
+			expected: []*Violation{
+				{Location: "users[0]", Field: "DeptId,Code", Value: "10,A", Message: "value '10,A' is duplicated in batch at users[2],users[3]", Check: "unique"},
+				{Location: "users[2]", Field: "DeptId,Code", Value: "10,A", Message: "value '10,A' is duplicated in batch at users[0],users[3]", Check: "unique"},
+				{Location: "users[3]", Field: "DeptId,Code", Value: "10,A", Message: "value '10,A' is duplicated in batch at users[0],users[2]", Check: "unique"},
+			},
+		},
+		{
+			description: "unique check disabled",
+			data: []*DuplicateRecord{
+				{Id: 1, Email: stringPtr("a@x.io")},
+				{Id: 1, Email: stringPtr("a@x.io")},
+			},
+			options: []Option{WithUnique(false)},
+		},
+	}
+	srv := New()
+	for _, testCase := range testCases {
+		validation, err := srv.Validate(context.Background(), db, testCase.data, append(testCase.options, WithLocation("users"))...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expected, validation.Violations, testCase.description)
+	}
+}
@@ -0,0 +1,744 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// truth represents SQL three-valued logic result
+type truth int8
+
+const (
+	truthUnknown = truth(iota)
+	truthFalse
+	truthTrue
+)
+
+type (
+	// operand returns expression value for supplied record column values, nil represents NULL
+	operand func(row map[string]interface{}) interface{}
+	// predicate returns expression truth for supplied record column values
+	predicate func(row map[string]interface{}) truth
+
+	exprNode struct {
+		value operand
+		pred  predicate
+		list  []operand
+	}
+
+	exprToken struct {
+		kind byte //i - identifier, q - quoted identifier, n - number, s - string, o - operator
+		text string
+	}
+
+	exprParser struct {
+		tokens  []*exprToken
+		pos     int
+		columns []string
+	}
+)
+
+func asTruth(flag bool) truth {
+	if flag {
+		return truthTrue
+	}
+	return truthFalse
+}
+
+// parseCheckExpr translates check constraint clause into predicate, it returns error for unsupported expressions
+func parseCheckExpr(clause string) (predicate, []string, error) {
+	clause = strings.TrimSpace(clause)
+	for _, suffix := range []string{" NOT VALID", " NO INHERIT"} { //postgres constraint definition attributes
+		clause = strings.TrimSuffix(clause, suffix)
+	}
+	tokens, err := tokenizeExpr(strings.ReplaceAll(clause, `\'`, `'`))
+	if err != nil {
+		return nil, nil, err
+	}
+	parser := &exprParser{tokens: tokens}
+	if parser.isKeyword("CHECK") {
+		parser.pos++
+	}
+	node, err := parser.parseOr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, nil, fmt.Errorf("unsupported expression near: %v", parser.tokens[parser.pos].text)
+	}
+	if node.pred == nil {
+		return nil, nil, fmt.Errorf("unsupported non boolean expression")
+	}
+	return node.pred, parser.columns, nil
+}
+
+func tokenizeExpr(expr string) ([]*exprToken, error) {
+	var result []*exprToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '`':
+			end := indexRune(runes, i+1, r)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated identifier: %v", string(runes[i:]))
+			}
+			result = append(result, &exprToken{kind: 'q', text: string(runes[i+1 : end])})
+			i = end + 1
+		case r == '[' && !isArrayBracket(result, runes, i):
+			end := indexRune(runes, i+1, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated identifier: %v", string(runes[i:]))
+			}
+			result = append(result, &exprToken{kind: 'q', text: string(runes[i+1 : end])})
+			i = end + 1
+		case r == '\'':
+			text, end, err := scanString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &exprToken{kind: 's', text: text})
+			i = end
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '-' || runes[i] == '+') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			result = append(result, &exprToken{kind: 'n', text: string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			ident := string(runes[start:i])
+			if i < len(runes) && runes[i] == '\'' && (strings.EqualFold(ident, "N") || strings.HasPrefix(ident, "_")) {
+				continue //national or charset introducer
+			}
+			result = append(result, &exprToken{kind: 'i', text: ident})
+		default:
+			op := string(r)
+			if i+1 < len(runes) {
+				switch pair := string(runes[i : i+2]); pair {
+				case "<=", ">=", "<>", "!=", "::", "||":
+					op = pair
+				}
+			}
+			result = append(result, &exprToken{kind: 'o', text: op})
+			i += len([]rune(op))
+		}
+	}
+	return result, nil
+}
+
+func isArrayBracket(tokens []*exprToken, runes []rune, pos int) bool {
+	if pos+1 < len(runes) && runes[pos+1] == ']' {
+		return true
+	}
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	return last.kind == 'i' && strings.EqualFold(last.text, "ARRAY")
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func scanString(runes []rune, pos int) (string, int, error) {
+	sb := strings.Builder{}
+	for i := pos + 1; i < len(runes); i++ {
+		if runes[i] == '\'' {
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				sb.WriteRune('\'')
+				i++
+				continue
+			}
+			return sb.String(), i + 1, nil
+		}
+		sb.WriteRune(runes[i])
+	}
+	return "", 0, fmt.Errorf("unterminated string: %v", string(runes[pos:]))
+}
+
+func (p *exprParser) peek() *exprToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *exprParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return token != nil && token.kind == 'i' && strings.EqualFold(token.text, keyword)
+}
+
+func (p *exprParser) isOperator(op string) bool {
+	token := p.peek()
+	return token != nil && token.kind == 'o' && token.text == op
+}
+
+func (p *exprParser) expectOperator(op string) error {
+	if !p.isOperator(op) {
+		return fmt.Errorf("expected '%v' at position %v", op, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *exprParser) parseOr() (*exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if left.pred == nil || right.pred == nil {
+			return nil, fmt.Errorf("unsupported OR operand")
+		}
+		l, r := left.pred, right.pred
+		left = &exprNode{pred: func(row map[string]interface{}) truth {
+			a, b := l(row), r(row)
+			if a == truthTrue || b == truthTrue {
+				return truthTrue
+			}
+			if a == truthFalse && b == truthFalse {
+				return truthFalse
+			}
+			return truthUnknown
+		}}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (*exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if left.pred == nil || right.pred == nil {
+			return nil, fmt.Errorf("unsupported AND operand")
+		}
+		l, r := left.pred, right.pred
+		left = &exprNode{pred: func(row map[string]interface{}) truth {
+			a, b := l(row), r(row)
+			if a == truthFalse || b == truthFalse {
+				return truthFalse
+			}
+			if a == truthTrue && b == truthTrue {
+				return truthTrue
+			}
+			return truthUnknown
+		}}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (*exprNode, error) {
+	if !p.isKeyword("NOT") {
+		return p.parseComparison()
+	}
+	p.pos++
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if node.pred == nil {
+		return nil, fmt.Errorf("unsupported NOT operand")
+	}
+	return &exprNode{pred: not(node.pred)}, nil
+}
+
+func not(pred predicate) predicate {
+	return func(row map[string]interface{}) truth {
+		switch pred(row) {
+		case truthTrue:
+			return truthFalse
+		case truthFalse:
+			return truthTrue
+		}
+		return truthUnknown
+	}
+}
+
+func (p *exprParser) parseComparison() (*exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	token := p.peek()
+	if token == nil {
+		return left, nil
+	}
+	if token.kind == 'o' {
+		switch op := token.text; op {
+		case "=", "<>", "!=", "<", "<=", ">", ">=":
+			p.pos++
+			if left.value == nil {
+				return nil, fmt.Errorf("unsupported %v operand", op)
+			}
+			if p.isKeyword("ANY") || p.isKeyword("SOME") || p.isKeyword("ALL") {
+				return p.parseQuantified(left.value, op)
+			}
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			if right.value == nil {
+				return nil, fmt.Errorf("unsupported %v operand", op)
+			}
+			return &exprNode{pred: comparison(left.value, right.value, op)}, nil
+		}
+		return left, nil
+	}
+	if token.kind != 'i' {
+		return left, nil
+	}
+	negated := false
+	if strings.EqualFold(token.text, "NOT") {
+		negated = true
+		p.pos++
+	}
+	var pred predicate
+	switch {
+	case p.isKeyword("IS") && !negated:
+		p.pos++
+		isNot := false
+		if p.isKeyword("NOT") {
+			isNot = true
+			p.pos++
+		}
+		if !p.isKeyword("NULL") {
+			return nil, fmt.Errorf("unsupported IS expression")
+		}
+		p.pos++
+		if left.value == nil {
+			return nil, fmt.Errorf("unsupported IS operand")
+		}
+		value := left.value
+		pred = func(row map[string]interface{}) truth {
+			return asTruth((value(row) == nil) != isNot)
+		}
+	case p.isKeyword("IN"):
+		p.pos++
+		if left.value == nil {
+			return nil, fmt.Errorf("unsupported IN operand")
+		}
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		pred = membership(left.value, list)
+	case p.isKeyword("BETWEEN"):
+		p.pos++
+		lower, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isKeyword("AND") {
+			return nil, fmt.Errorf("expected BETWEEN ... AND")
+		}
+		p.pos++
+		upper, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if left.value == nil || lower.value == nil || upper.value == nil {
+			return nil, fmt.Errorf("unsupported BETWEEN operand")
+		}
+		gte, lte := comparison(left.value, lower.value, ">="), comparison(left.value, upper.value, "<=")
+		pred = func(row map[string]interface{}) truth {
+			a, b := gte(row), lte(row)
+			if a == truthFalse || b == truthFalse {
+				return truthFalse
+			}
+			if a == truthTrue && b == truthTrue {
+				return truthTrue
+			}
+			return truthUnknown
+		}
+	default:
+		if negated {
+			return nil, fmt.Errorf("unsupported expression: NOT %v", tokenText(p.peek()))
+		}
+		if isClauseKeyword(token.text) {
+			return left, nil
+		}
+		return nil, fmt.Errorf("unsupported expression: %v", token.text)
+	}
+	if negated {
+		pred = not(pred)
+	}
+	return &exprNode{pred: pred}, nil
+}
+
+func tokenText(token *exprToken) string {
+	if token == nil {
+		return ""
+	}
+	return token.text
+}
+
+func isClauseKeyword(text string) bool {
+	switch strings.ToUpper(text) {
+	case "AND", "OR":
+		return true
+	}
+	return false
+}
+
+// parseQuantified parses = ANY (ARRAY[...]) and <> ALL (ARRAY[...]) used by postgres for IN lists
+func (p *exprParser) parseQuantified(value operand, op string) (*exprNode, error) {
+	quantifier := strings.ToUpper(p.peek().text)
+	p.pos++
+	list, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case op == "=" && quantifier != "ALL":
+		return &exprNode{pred: membership(value, list)}, nil
+	case (op == "<>" || op == "!=") && quantifier == "ALL":
+		return &exprNode{pred: not(membership(value, list))}, nil
+	}
+	return nil, fmt.Errorf("unsupported %v %v expression", op, quantifier)
+}
+
+func (p *exprParser) parseList() ([]operand, error) {
+	if err := p.expectOperator("("); err != nil {
+		return nil, err
+	}
+	node, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	var list []operand
+	if node.list != nil {
+		list = node.list
+	} else {
+		if node.value == nil {
+			return nil, fmt.Errorf("unsupported list item")
+		}
+		list = append(list, node.value)
+		for p.isOperator(",") {
+			p.pos++
+			item, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			if item.value == nil {
+				return nil, fmt.Errorf("unsupported list item")
+			}
+			list = append(list, item.value)
+		}
+	}
+	return list, p.expectOperator(")")
+}
+
+func (p *exprParser) parseOperand() (*exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("::") {
+		p.pos++
+		if err = p.skipType(); err != nil {
+			return nil, err
+		}
+	}
+	if token := p.peek(); token != nil && token.kind == 'o' {
+		switch token.text {
+		case "+", "-", "*", "/", "%", "||":
+			return nil, fmt.Errorf("unsupported arithmetic operator: %v", token.text)
+		}
+	}
+	return node, nil
+}
+
+// skipType skips cast type i.e. ::character varying(10)[]
+func (p *exprParser) skipType() error {
+	if token := p.peek(); token == nil || (token.kind != 'i' && token.kind != 'q') {
+		return fmt.Errorf("expected cast type")
+	}
+	p.pos++
+	for token := p.peek(); token != nil && token.kind == 'i' && !isReserved(token.text); token = p.peek() {
+		p.pos++
+	}
+	if p.isOperator("(") {
+		for p.pos < len(p.tokens) && !p.isOperator(")") {
+			p.pos++
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return err
+		}
+	}
+	if p.isOperator("[") {
+		p.pos++
+		return p.expectOperator("]")
+	}
+	return nil
+}
+
+func isReserved(text string) bool {
+	switch strings.ToUpper(text) {
+	case "AND", "OR", "NOT", "IS", "IN", "BETWEEN", "LIKE", "ANY", "SOME", "ALL", "NULL":
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) parsePrimary() (*exprNode, error) {
+	token := p.peek()
+	if token == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+	switch token.kind {
+	case 'n':
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, err
+		}
+		return literal(number), nil
+	case 's':
+		return literal(token.text), nil
+	case 'q':
+		return p.column(token.text), nil
+	case 'o':
+		switch token.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expectOperator(")")
+		case "-":
+			if next := p.peek(); next != nil && next.kind == 'n' {
+				p.pos++
+				number, err := strconv.ParseFloat(next.text, 64)
+				if err != nil {
+					return nil, err
+				}
+				return literal(-number), nil
+			}
+		}
+		return nil, fmt.Errorf("unsupported operator: %v", token.text)
+	}
+	switch strings.ToUpper(token.text) {
+	case "NULL":
+		return literal(nil), nil
+	case "TRUE":
+		return literal(true), nil
+	case "FALSE":
+		return literal(false), nil
+	case "ARRAY":
+		if err := p.expectOperator("["); err != nil {
+			return nil, err
+		}
+		node := &exprNode{list: []operand{}}
+		for !p.isOperator("]") {
+			item, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			if item.value == nil {
+				return nil, fmt.Errorf("unsupported array item")
+			}
+			node.list = append(node.list, item.value)
+			if p.isOperator(",") {
+				p.pos++
+			} else if !p.isOperator("]") {
+				return nil, fmt.Errorf("expected ']'")
+			}
+		}
+		p.pos++
+		return node, nil
+	}
+	if p.isOperator("(") {
+		return p.parseFunction(token.text)
+	}
+	if isReserved(token.text) {
+		return nil, fmt.Errorf("unsupported expression: %v", token.text)
+	}
+	return p.column(token.text), nil
+}
+
+func (p *exprParser) parseFunction(name string) (*exprNode, error) {
+	p.pos++
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	if arg.value == nil {
+		return nil, fmt.Errorf("unsupported %v argument", name)
+	}
+	value := arg.value
+	var fn func(text string) interface{}
+	switch strings.ToUpper(name) {
+	case "LENGTH", "CHAR_LENGTH", "CHARACTER_LENGTH", "LEN":
+		fn = func(text string) interface{} { return float64(utf8.RuneCountInString(text)) }
+	case "UPPER":
+		fn = func(text string) interface{} { return strings.ToUpper(text) }
+	case "LOWER":
+		fn = func(text string) interface{} { return strings.ToLower(text) }
+	case "TRIM", "BTRIM":
+		fn = func(text string) interface{} { return strings.TrimSpace(text) }
+	default:
+		return nil, fmt.Errorf("unsupported function: %v", name)
+	}
+	return &exprNode{value: func(row map[string]interface{}) interface{} {
+		arg := value(row)
+		if arg == nil {
+			return nil
+		}
+		text, ok := asText(arg)
+		if !ok {
+			text = fmt.Sprintf("%v", arg)
+		}
+		return fn(text)
+	}}, nil
+}
+
+func (p *exprParser) column(name string) *exprNode {
+	key := strings.ToLower(name)
+	found := false
+	for _, candidate := range p.columns {
+		if candidate == key {
+			found = true
+			break
+		}
+	}
+	if !found {
+		p.columns = append(p.columns, key)
+	}
+	return &exprNode{value: func(row map[string]interface{}) interface{} {
+		return row[key]
+	}}
+}
+
+func literal(value interface{}) *exprNode {
+	return &exprNode{value: func(row map[string]interface{}) interface{} {
+		return value
+	}}
+}
+
+func comparison(left, right operand, op string) predicate {
+	return func(row map[string]interface{}) truth {
+		a, b := left(row), right(row)
+		if a == nil || b == nil {
+			return truthUnknown
+		}
+		result, ok := compareSQL(a, b)
+		if !ok {
+			return truthUnknown
+		}
+		switch op {
+		case "=":
+			return asTruth(result == 0)
+		case "<>", "!=":
+			return asTruth(result != 0)
+		case "<":
+			return asTruth(result < 0)
+		case "<=":
+			return asTruth(result <= 0)
+		case ">":
+			return asTruth(result > 0)
+		case ">=":
+			return asTruth(result >= 0)
+		}
+		return truthUnknown
+	}
+}
+
+func membership(value operand, list []operand) predicate {
+	return func(row map[string]interface{}) truth {
+		actual := value(row)
+		if actual == nil {
+			return truthUnknown
+		}
+		result := truthFalse
+		for _, item := range list {
+			candidate := item(row)
+			if candidate == nil {
+				result = truthUnknown
+				continue
+			}
+			if cmp, ok := compareSQL(actual, candidate); ok && cmp == 0 {
+				return truthTrue
+			}
+		}
+		return result
+	}
+}
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05", "2006-01-02"}
+
+// compareSQL compares record value with literal, coercing literal to record value type
+func compareSQL(value, other interface{}) (int, bool) {
+	if flag, ok := value.(bool); ok {
+		value = boolNumber(flag)
+	}
+	if flag, ok := other.(bool); ok {
+		other = boolNumber(flag)
+	}
+	if ts, ok := value.(time.Time); ok {
+		if text, ok := other.(string); ok {
+			for _, layout := range timeLayouts {
+				if parsed, err := time.ParseInLocation(layout, text, ts.Location()); err == nil {
+					return ts.Compare(parsed), true
+				}
+			}
+			return 0, false
+		}
+	}
+	if text, ok := other.(string); ok {
+		if _, isNumber := asFloat(value); isNumber {
+			if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+				other = number
+			}
+		}
+	}
+	if text, ok := value.(string); ok {
+		if _, isNumber := asFloat(other); isNumber {
+			if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+				value = number
+			}
+		}
+	}
+	if result, ok := compare(value, other); ok {
+		return result, true
+	}
+	return strings.Compare(fmt.Sprintf("%v", value), fmt.Sprintf("%v", other)), true
+}
+
+func boolNumber(flag bool) float64 {
+	if flag {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseCheckExpr(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var testCases = []struct {
+		description string
+		clause      string
+		columns     []string
+		row         map[string]interface{}
+		expect      truth
+		hasError    bool
+	}{
+		{
+			description: "pg comparison with cast",
+			clause:      "CHECK ((price > (0)::numeric))",
+			columns:     []string{"price"},
+			row:         map[string]interface{}{"price": 1.5},
+			expect:      truthTrue,
+		},
+		{
+			description: "pg any array",
+			clause:      "CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'inactive'::character varying])::text[])))",
+			columns:     []string{"status"},
+			row:         map[string]interface{}{"status": "deleted"},
+			expect:      truthFalse,
+		},
+		{
+			description: "pg not valid",
+			clause:      "CHECK ((qty >= 0)) NOT VALID",
+			columns:     []string{"qty"},
+			row:         map[string]interface{}{"qty": -1},
+			expect:      truthFalse,
+		},
+		{
+			description: "mysql in with charset introducer",
+			clause:      "(`status` in (_utf8mb4\\'active\\',_utf8mb4\\'inactive\\'))",
+			columns:     []string{"status"},
+			row:         map[string]interface{}{"status": "active"},
+			expect:      truthTrue,
+		},
+		{
+			description: "mysql char_length",
+			clause:      "(char_length(`name`) between 2 and 5)",
+			columns:     []string{"name"},
+			row:         map[string]interface{}{"name": "abcdef"},
+			expect:      truthFalse,
+		},
+		{
+			description: "sqlserver brackets",
+			clause:      "([qty]>=(0) AND [qty]<=(100) OR [kind]=N'bulk')",
+			columns:     []string{"qty", "kind"},
+			row:         map[string]interface{}{"qty": 200, "kind": "bulk"},
+			expect:      truthTrue,
+		},
+		{
+			description: "sqlserver len",
+			clause:      "(len([code])=(3))",
+			columns:     []string{"code"},
+			row:         map[string]interface{}{"code": "ab"},
+			expect:      truthFalse,
+		},
+		{
+			description: "oracle not null",
+			clause:      `"NAME" IS NOT NULL`,
+			columns:     []string{"name"},
+			row:         map[string]interface{}{"name": nil},
+			expect:      truthFalse,
+		},
+		{
+			description: "oracle not in",
+			clause:      "status NOT IN ('X', 'Y')",
+			columns:     []string{"status"},
+			row:         map[string]interface{}{"status": "X"},
+			expect:      truthFalse,
+		},
+		{
+			description: "null is unknown",
+			clause:      "price > 0",
+			columns:     []string{"price"},
+			row:         map[string]interface{}{"price": nil},
+			expect:      truthUnknown,
+		},
+		{
+			description: "cross column",
+			clause:      "CHECK ((end_date > start_date))",
+			columns:     []string{"end_date", "start_date"},
+			row:         map[string]interface{}{"start_date": ts, "end_date": ts},
+			expect:      truthFalse,
+		},
+		{
+			description: "time literal",
+			clause:      "created >= '2024-01-01'",
+			columns:     []string{"created"},
+			row:         map[string]interface{}{"created": ts},
+			expect:      truthTrue,
+		},
+		{
+			description: "arithmetic is not supported",
+			clause:      "price * qty < 1000",
+			hasError:    true,
+		},
+		{
+			description: "like is not supported",
+			clause:      "email LIKE '%@%'",
+			hasError:    true,
+		},
+		{
+			description: "unknown function is not supported",
+			clause:      "regexp_like(email, '@')",
+			hasError:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		pred, columns, err := parseCheckExpr(testCase.clause)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.columns, columns, testCase.description)
+		assert.EqualValues(t, testCase.expect, pred(testCase.row), testCase.description)
+	}
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 )
 
@@ -11,6 +12,11 @@ type (
 		Location    string
 		Shallow     bool
 		SetMarker   *option.SetMarker
+		Table       string
+		Columns     []sink.Column
+		//CheckConstraints enables table check constraints validation
+		CheckConstraints bool
+		Constraints      []sink.Check
 	}
 	Option func(c *Options)
 )
@@ -49,6 +55,46 @@ func WithShallow(flag bool) Option {
 	}
 }
 
+// WithTableSchema validates records against table column metadata: length, precision, not null and enum/set values
+func WithTableSchema(table string) Option {
+	return func(c *Options) {
+		c.Table = table
+	}
+}
+
+// WithTableColumns validates records against supplied table columns, instead of loading them from database
+func WithTableColumns(table string, columns []sink.Column) Option {
+	return func(c *Options) {
+		c.Table = table
+		c.Columns = columns
+	}
+}
+
+// WithCheckConstraints validates records against table check constraints (pg, mysql 8, sqlserver, oracle),
+// it requires WithTableSchema or WithTableColumns, constraints that can not be evaluated are reported as Validation.Unchecked
+func WithCheckConstraints(flag bool) Option {
+	return func(c *Options) {
+		c.CheckConstraints = flag
+	}
+}
+
+// WithTableCheckConstraints validates records against supplied check constraints, instead of loading them from database
+func WithTableCheckConstraints(constraints []sink.Check) Option {
+	return func(c *Options) {
+		c.CheckConstraints = true
+		c.Constraints = constraints
+	}
+}
+
+func withoutTableSchema() Option {
+	return func(c *Options) {
+		c.Table = ""
+		c.Columns = nil
+		c.CheckConstraints = false
+		c.Constraints = nil
+	}
+}
+
 func NewOptions() *Options {
 	return &Options{
 		CheckUnique: true,
@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+type (
+	// SchemaCheck represents record field check derived from table column metadata
+	SchemaCheck struct {
+		Field     *xunsafe.Field
+		Column    string
+		ErrorMsg  string
+		NotNull   bool
+		Length    int64
+		Precision int64
+		Scale     int64
+		Values    []string
+		IsSet     bool
+	}
+
+	schemaKey struct {
+		Type  reflect.Type
+		Table string
+	}
+)
+
+// NewSchemaChecks creates checks for record type fields matching table columns
+func NewSchemaChecks(recordType reflect.Type, columns []sink.Column) ([]*SchemaCheck, error) {
+	structColumns, err := io.StructColumns(recordType)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*sink.Column, len(columns))
+	for i := range columns {
+		byName[strings.ToLower(columns[i].Name)] = &columns[i]
+	}
+
+	var result []*SchemaCheck
+	for _, structColumn := range structColumns {
+		column, ok := byName[strings.ToLower(structColumn.Name())]
+		if !ok {
+			continue
+		}
+		fielder, ok := structColumn.(io.Fielder)
+		if !ok {
+			continue
+		}
+		fields := fielder.Fields()
+		check := &SchemaCheck{Field: fields[len(fields)-1], Column: column.Name}
+		tag := structColumn.Tag()
+		if tag != nil {
+			if tag.Transient {
+				continue
+			}
+			check.ErrorMsg = tag.ErrorMgs
+		}
+
+		check.NotNull = !column.IsNullable() && !hasDefault(column) && !column.Autoincrement() &&
+			(tag == nil || (!tag.Autoincrement && tag.Sequence == ""))
+		if column.Length != nil && *column.Length > 0 {
+			check.Length = *column.Length
+		}
+		if isDecimal(column.Type) && column.Precision != nil && *column.Precision > 0 {
+			check.Precision = *column.Precision
+			if column.Scale != nil {
+				check.Scale = *column.Scale
+			}
+		}
+		check.Values, check.IsSet = column.EnumValues()
+
+		if check.NotNull || check.Length > 0 || check.Precision > 0 || len(check.Values) > 0 {
+			result = append(result, check)
+		}
+	}
+	return result, nil
+}
+
+func hasDefault(column *sink.Column) bool {
+	return column.Default != nil && *column.Default != ""
+}
+
+func isDecimal(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "decimal", "numeric", "number", "dec":
+		return true
+	}
+	return false
+}
+
+func (s *Service) schemaChecksFor(ctx context.Context, db *sql.DB, recordType reflect.Type, options *Options) ([]*SchemaCheck, error) {
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	key := schemaKey{Type: recordType, Table: options.Table}
+	s.mux.RLock()
+	checks, ok := s.schemaChecks[key]
+	s.mux.RUnlock()
+	if ok && options.Columns == nil {
+		return checks, nil
+	}
+	columns := options.Columns
+	if columns == nil {
+		var err error
+		if columns, err = s.tableColumns(ctx, db, options.Table); err != nil {
+			return nil, err
+		}
+	}
+	checks, err := NewSchemaChecks(recordType, columns)
+	if err != nil {
+		return nil, err
+	}
+	if options.Columns == nil {
+		s.mux.Lock()
+		s.schemaChecks[key] = checks
+		s.mux.Unlock()
+	}
+	return checks, nil
+}
+
+func (s *Service) tableColumns(ctx context.Context, db *sql.DB, table string) ([]sink.Column, error) {
+	dialect, err := config.Dialect(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	session, err := config.Session(ctx, db, dialect)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := config.Columns(ctx, session, db, table, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %v columns: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("failed to lookup %v columns: table not found", table)
+	}
+	return columns, nil
+}
+
+func (s *Service) checkSchema(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, recordType reflect.Type, violations *Validation, options *Options) error {
+	if options.Table == "" {
+		return nil
+	}
+	checks, err := s.schemaChecksFor(ctx, db, recordType, options)
+	if err != nil {
+		return err
+	}
+	setMarker := options.SetMarker
+	for i := 0; i < count; i++ {
+		itemPath := path.AppendIndex(i)
+		recordPtr := xunsafe.AsPointer(at(i))
+		for _, check := range checks {
+			if setMarker != nil && setMarker.Marker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
+				continue
+			}
+			check.validate(itemPath.AppendField(check.Field.Name), fieldValue(check.Field, recordPtr), violations)
+		}
+	}
+	return nil
+}
+
+func (c *SchemaCheck) validate(path *Path, value interface{}, violations *Validation) {
+	value = derefValue(value)
+	if value == nil {
+		if c.NotNull {
+			violations.AppendNotNull(path, c.Field.Name, c.ErrorMsg)
+		}
+		return
+	}
+	if c.Length > 0 {
+		if text, ok := asText(value); ok {
+			if length := utf8.RuneCountInString(text); int64(length) > c.Length {
+				violations.AppendCheck(path, c.Field.Name, value, CheckKidLength, c.ErrorMsg,
+					fmt.Sprintf("value length %v exceeds column %v max length %v", length, c.Column, c.Length))
+			}
+		}
+	}
+	if c.Precision > 0 {
+		if digits, ok := integerDigits(value); ok && digits > c.Precision-c.Scale {
+			violations.AppendCheck(path, c.Field.Name, value, CheckKidPrecision, c.ErrorMsg,
+				fmt.Sprintf("value '%v' overflows column %v precision (%v,%v)", value, c.Column, c.Precision, c.Scale))
+		}
+	}
+	if len(c.Values) > 0 {
+		if text, ok := asText(value); ok && !c.allowed(text) {
+			violations.AppendCheck(path, c.Field.Name, value, CheckKidEnum, c.ErrorMsg,
+				fmt.Sprintf("value '%v' is not one of column %v values: %v", value, c.Column, strings.Join(c.Values, ",")))
+		}
+	}
+}
+
+func (c *SchemaCheck) allowed(text string) bool {
+	items := []string{text}
+	if c.IsSet {
+		if text == "" {
+			return true
+		}
+		items = strings.Split(text, ",")
+	}
+	for _, item := range items {
+		found := false
+		for _, candidate := range c.Values {
+			if strings.EqualFold(candidate, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldValue reads record field value through reflect, nil pointer fields return nil
+func fieldValue(field *xunsafe.Field, recordPtr unsafe.Pointer) interface{} {
+	return derefValue(rawFieldValue(field, recordPtr))
+}
+
+// rawFieldValue reads record field value through reflect as is, pointer fields are not dereferenced
+func rawFieldValue(field *xunsafe.Field, recordPtr unsafe.Pointer) interface{} {
+	return reflect.NewAt(field.Type, field.Pointer(recordPtr)).Elem().Interface()
+}
+
+// derefValue dereferences pointers, it returns nil for nil pointer
+func derefValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	rValue := reflect.ValueOf(value)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return nil
+		}
+		rValue = rValue.Elem()
+	}
+	return rValue.Interface()
+}
+
+func asText(value interface{}) (string, bool) {
+	switch actual := value.(type) {
+	case string:
+		return actual, true
+	case []byte:
+		return string(actual), true
+	}
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() == reflect.String {
+		return rValue.String(), true
+	}
+	return "", false
+}
+
+// integerDigits returns number of digits before decimal point
+func integerDigits(value interface{}) (int64, bool) {
+	var text string
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		text = strconv.FormatInt(rValue.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		text = strconv.FormatUint(rValue.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		text = strconv.FormatFloat(rValue.Float(), 'f', -1, 64)
+	case reflect.String:
+		text = strings.TrimSpace(rValue.String())
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return 0, false
+		}
+		if strings.ContainsAny(text, "eE") {
+			f, _ := strconv.ParseFloat(text, 64)
+			text = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	default:
+		return 0, false
+	}
+	text = strings.TrimLeft(text, "+-")
+	if index := strings.IndexByte(text, '.'); index != -1 {
+		text = text[:index]
+	}
+	return int64(len(strings.TrimLeft(text, "0"))), true
+}
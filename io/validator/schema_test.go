@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/metadata/sink"
+	"path"
+	"testing"
+)
+
+type SchemaRecord struct {
+	Id     int      `sqlx:"id,primaryKey"`
+	Name   *string  `sqlx:"name"`
+	Price  *float64 `sqlx:"price"`
+	Status string   `sqlx:"status"`
+	Tags   string   `sqlx:"tags" json:",omitempty"`
+}
+
+func TestService_Validate_TableColumns(t *testing.T) {
+	length, precision, scale := int64(5), int64(5), int64(2)
+	columns := []sink.Column{
+		{Name: "id", Type: "int", Nullable: "NO", Key: "PRI"},
+		{Name: "name", Type: "varchar", Length: &length, Nullable: "NO"},
+		{Name: "price", Type: "decimal", Precision: &precision, Scale: &scale, Nullable: "YES"},
+		{Name: "status", Type: "enum", ColumnType: "enum('active','it''s done')", Nullable: "YES"},
+		{Name: "tags", Type: "set", ColumnType: "set('a','b','c')", Nullable: "YES"},
+	}
+
+	var testCases = []struct {
+		description string
+		data        interface{}
+		expected    []*Violation
+	}{
+		{
+			description: "valid",
+			data:        &SchemaRecord{Id: 1, Name: stringPtr("héllo"), Price: floatPtr(999.999), Status: "ACTIVE", Tags: "a,c"},
+		},
+		{
+			description: "not null without default",
+			data:        &SchemaRecord{Id: 1, Status: "active"},
+			expected:    []*Violation{{Location: "products.Name", Field: "Name", Message: "value is null", Check: "notnull"}},
+		},
+		{
+			description: "length, precision and enum",
+			data: []*SchemaRecord{
+				{Id: 1, Name: stringPtr("abc"), Status: "it's done"},
+				{Id: 2, Name: stringPtr("abcdef"), Price: floatPtr(1000.5), Status: "pending", Tags: "a,d"},
+			},
+			expected: []*Violation{
+				{Location: "products[1].Name", Field: "Name", Value: "abcdef", Message: "value length 6 exceeds column name max length 5", Check: "length"},
+				{Location: "products[1].Price", Field: "Price", Value: 1000.5, Message: "value '1000.5' overflows column price precision (5,2)", Check: "precision"},
+				{Location: "products[1].Status", Field: "Status", Value: "pending", Message: "value 'pending' is not one of column status values: active,it's done", Check: "enum"},
+				{Location: "products[1].Tags", Field: "Tags", Value: "a,d", Message: "value 'a,d' is not one of column tags values: a,b,c", Check: "enum"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		srv := New()
+		validation, err := srv.Validate(context.Background(), nil, testCase.data, WithTableColumns("products", columns), WithLocation("products"))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(testCase.expected) > 0, validation.Failed, testCase.description)
+		assert.EqualValues(t, testCase.expected, validation.Violations, testCase.description)
+	}
+}
+
+func TestService_Validate_TableSchema(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "schema.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE products (id INTEGER PRIMARY KEY, name TEXT NOT NULL, price DECIMAL(5,2), status TEXT NOT NULL DEFAULT 'active', tags TEXT)")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	srv := New()
+	validation, err := srv.Validate(context.Background(), db, []*SchemaRecord{{Id: 1, Name: stringPtr("a")}, {Id: 2}}, WithTableSchema("products"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, validation.Failed)
+	if assert.Len(t, validation.Violations, 1) {
+		assert.EqualValues(t, "Name", validation.Violations[0].Field)
+		assert.EqualValues(t, "notnull", validation.Violations[0].Check)
+	}
+
+	_, err = srv.Validate(context.Background(), db, &SchemaRecord{Id: 1}, WithTableSchema("missing"))
+	assert.NotNil(t, err)
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 	"github.com/viant/xunsafe"
 	"reflect"
@@ -17,8 +18,11 @@ import (
 
 type (
 	Service struct {
-		checks map[reflect.Type]*Checks
-		mux    sync.RWMutex
+		checks       map[reflect.Type]*Checks
+		schemaChecks map[schemaKey][]*SchemaCheck
+		dialects     map[*sql.DB]*info.Dialect
+		constraints  map[schemaKey][]*CheckConstraint
+		mux          sync.RWMutex
 	}
 )
 
@@ -74,12 +78,27 @@ func (s *Service) Validate(ctx context.Context, db *sql.DB, any interface{}, opt
 			path.IsSlice = true
 		}
 	}
+	s.checkNotNull(path, valueAt, count, checks.NoNull, &ret, options)
+	s.checkValues(path, valueAt, count, checks.Values, &ret, options)
+	s.checkDuplicates(path, valueAt, count, checks.Duplicates, &ret, options)
 	if err = s.checkUniques(ctx, path, db, valueAt, count, checks.Unique, &ret, options); err != nil {
 		return nil, err
 	}
 	if err = s.checkRefs(ctx, path, db, valueAt, count, checks.RefKey, &ret, options); err != nil {
 		return nil, err
 	}
+	if err = s.checkCompositeUniques(ctx, path, db, valueAt, count, checks.CompositeUnique, &ret, options); err != nil {
+		return nil, err
+	}
+	if err = s.checkCompositeRefs(ctx, path, db, valueAt, count, checks.CompositeRef, &ret, options); err != nil {
+		return nil, err
+	}
+	if err = s.checkSchema(ctx, path, db, valueAt, count, recordType, &ret, options); err != nil {
+		return nil, err
+	}
+	if err = s.checkConstraints(ctx, path, db, valueAt, count, recordType, &ret, options); err != nil {
+		return nil, err
+	}
 
 	if !options.Shallow {
 		if err := s.validateFields(ctx, db, recordType, path, valueAt, count, &ret, opts); err != nil {
@@ -133,7 +152,7 @@ func (s *Service) validateFields(ctx context.Context, db *sql.DB, recordType ref
 			if values == nil {
 				continue
 			}
-			validation, err := s.Validate(ctx, db, values, append(options, WithLocation(field.Name))...)
+			validation, err := s.Validate(ctx, db, values, append(options, WithLocation(field.Name), withoutTableSchema())...)
 			if err != nil {
 				return err
 			}
@@ -201,6 +220,26 @@ func (s *Service) buildFieldSlice(sliceType reflect.Type, count int, valueAt io.
 	return sliceValue.Interface()
 }
 
+// checkNotNull reports nil values of required fields
+func (s *Service) checkNotNull(path *Path, at io.ValueAccessor, count int, checks []*Check, violations *Validation, options *Options) {
+	if len(checks) == 0 {
+		return
+	}
+	setMarker := options.SetMarker
+	for i := 0; i < count; i++ {
+		itemPath := path.AppendIndex(i)
+		recordPtr := xunsafe.AsPointer(at(i))
+		for _, check := range checks {
+			if setMarker != nil && setMarker.Marker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
+				continue
+			}
+			if fieldValue(check.Field, recordPtr) == nil {
+				violations.AppendNotNull(itemPath.AppendField(check.Field.Name), check.Field.Name, check.ErrorMsg)
+			}
+		}
+	}
+}
+
 func (s *Service) checkUniques(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, checks []*Check, violations *Validation, options *Options) error {
 	if len(checks) == 0 || !options.CheckUnique {
 		return nil
@@ -229,7 +268,7 @@ func (s *Service) checkUnique(ctx context.Context, path *Path, db *sql.DB, at io
 	var index = map[interface{}]bool{}
 	err = reader.QueryAll(ctx, func(record interface{}) error {
 		recordPtr := xunsafe.AsPointer(record)
-		value := check.CheckField.Value(recordPtr)
+		value := rawFieldValue(check.CheckField, recordPtr)
 		index[mapKey(value)] = true
 		return nil
 	}, queryCtx.values...)
@@ -258,7 +297,7 @@ func (s *Service) buildUniqueMatchContext(check *Check, count int, path *Path, a
 		if setMarker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
 			continue
 		}
-		value := check.Field.Value(recordPtr)
+		value := rawFieldValue(check.Field, recordPtr)
 		if isNil(value) {
 			continue //unique is null and not required skipping validation
 		}
@@ -302,7 +341,7 @@ func (s *Service) checkRef(ctx context.Context, path *Path, db *sql.DB, at io.Va
 	var index = map[interface{}]bool{}
 	err = reader.QueryAll(ctx, func(record interface{}) error {
 		recordPtr := xunsafe.AsPointer(record)
-		value := check.CheckField.Value(recordPtr)
+		value := rawFieldValue(check.CheckField, recordPtr)
 		index[mapKey(value)] = true
 		return nil
 	}, queryCtx.values...)
@@ -332,7 +371,7 @@ func (s *Service) buildCheckRefQueryContext(check *Check, count int, path *Path,
 		if setMarker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
 			continue
 		}
-		value := check.Field.Value(recordPtr)
+		value := rawFieldValue(check.Field, recordPtr)
 		if isNil(value) {
 			continue //ref key is null and not required skipping validation
 		}
@@ -382,5 +421,5 @@ func mapKey(value interface{}) interface{} {
 
 // New creates a new validation service
 func New() *Service {
-	return &Service{checks: map[reflect.Type]*Checks{}}
+	return &Service{checks: map[reflect.Type]*Checks{}, schemaChecks: map[schemaKey][]*SchemaCheck{}, dialects: map[*sql.DB]*info.Dialect{}, constraints: map[schemaKey][]*CheckConstraint{}}
 }
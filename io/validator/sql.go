@@ -79,11 +79,11 @@ func (p *queryContext) addCriteria(columns []*io.Column, recUPtr unsafe.Pointer,
 		field := fields[len(fields)-1]
 
 		fieldPath := itemPath.AppendField(field.Name)
-		fieldValue := field.Value(recUPtr)
+		value := rawFieldValue(field, recUPtr)
 
-		p.values = append(p.values, fieldValue)
-		p.index[mapKey(fieldValue)] = &queryValue{
-			value: fieldValue,
+		p.values = append(p.values, value)
+		p.index[mapKey(value)] = &queryValue{
+			value: value,
 			field: field.Name,
 			path:  fieldPath,
 		}
@@ -19,6 +19,7 @@ type (
 	Validation struct {
 		Violations []*Violation
 		Failed     bool
+		Unchecked  []*UncheckedConstraint `json:",omitempty"`
 	}
 )
 
@@ -84,6 +85,23 @@ func (e *Validation) AppendRef(path *Path, field string, value interface{}, msg
 	})
 }
 
+// AppendCheck appends violation for supplied check, custom msg can use $value placeholder
+func (e *Validation) AppendCheck(path *Path, field string, value interface{}, check CheckKid, msg, defaultMsg string) {
+	value = derefIfNeeded(value)
+	if msg == "" {
+		msg = defaultMsg
+	} else {
+		msg = strings.Replace(msg, "$value", fmt.Sprintf("%v", value), 1)
+	}
+	e.Violations = append(e.Violations, &Violation{
+		Location: path.String(),
+		Field:    field,
+		Value:    value,
+		Message:  msg,
+		Check:    string(check),
+	})
+}
+
 func (e *Validation) String() string {
 	if e == nil || len(e.Violations) == 0 {
 		return ""
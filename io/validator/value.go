@@ -0,0 +1,246 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+type (
+	// ValueCheck represents in process field value check defined with min, max, minLength, maxLength, pattern,
+	// oneOf and cross field (eqField, neField, gtField, gteField, ltField, lteField) tags
+	ValueCheck struct {
+		Field       *xunsafe.Field
+		ErrorMsg    string
+		Min         *float64
+		Max         *float64
+		MinLength   *int
+		MaxLength   *int
+		Pattern     *regexp.Regexp
+		OneOf       []string
+		Comparisons []*FieldComparison
+	}
+
+	// FieldComparison represents cross field comparison
+	FieldComparison struct {
+		Op    string
+		Field *xunsafe.Field
+	}
+)
+
+var comparisonNames = map[string]string{
+	"eq":  "equal to",
+	"ne":  "not equal to",
+	"gt":  "greater than",
+	"gte": "greater than or equal to",
+	"lt":  "less than",
+	"lte": "less than or equal to",
+}
+
+// NewValueCheck creates value check for supplied tag or nil if tag does not define any
+func NewValueCheck(field *xunsafe.Field, tag *io.Tag, fieldByName func(name string) *xunsafe.Field) (*ValueCheck, error) {
+	if tag.Min == "" && tag.Max == "" && tag.MinLength == "" && tag.MaxLength == "" && tag.Pattern == "" && len(tag.OneOf) == 0 && len(tag.Comparisons) == 0 {
+		return nil, nil
+	}
+	var err error
+	check := &ValueCheck{Field: field, ErrorMsg: tag.ErrorMgs, OneOf: tag.OneOf}
+	if check.Min, err = parseFloat(field, "min", tag.Min); err != nil {
+		return nil, err
+	}
+	if check.Max, err = parseFloat(field, "max", tag.Max); err != nil {
+		return nil, err
+	}
+	if check.MinLength, err = parseInt(field, "minLength", tag.MinLength); err != nil {
+		return nil, err
+	}
+	if check.MaxLength, err = parseInt(field, "maxLength", tag.MaxLength); err != nil {
+		return nil, err
+	}
+	if tag.Pattern != "" {
+		if check.Pattern, err = regexp.Compile(tag.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid %v pattern: %w", field.Name, err)
+		}
+	}
+	for _, comparison := range tag.Comparisons {
+		other := fieldByName(comparison.Field)
+		if other == nil {
+			return nil, fmt.Errorf("invalid %v %vField: field %v not found", field.Name, comparison.Op, comparison.Field)
+		}
+		check.Comparisons = append(check.Comparisons, &FieldComparison{Op: comparison.Op, Field: other})
+	}
+	return check, nil
+}
+
+func parseFloat(field *xunsafe.Field, name, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v %v: %w", field.Name, name, err)
+	}
+	return &result, nil
+}
+
+func parseInt(field *xunsafe.Field, name, value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v %v: %w", field.Name, name, err)
+	}
+	return &result, nil
+}
+
+func (s *Service) checkValues(path *Path, at io.ValueAccessor, count int, checks []*ValueCheck, violations *Validation, options *Options) {
+	if len(checks) == 0 {
+		return
+	}
+	setMarker := options.SetMarker
+	for i := 0; i < count; i++ {
+		itemPath := path.AppendIndex(i)
+		recordPtr := xunsafe.AsPointer(at(i))
+		for _, check := range checks {
+			if setMarker != nil && setMarker.Marker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
+				continue
+			}
+			check.validate(itemPath.AppendField(check.Field.Name), recordPtr, violations)
+		}
+	}
+}
+
+func (c *ValueCheck) validate(path *Path, ptr unsafe.Pointer, violations *Validation) {
+	value := fieldValue(c.Field, ptr)
+	if value == nil {
+		return //presence is validated with required tag
+	}
+	if number, ok := asFloat(value); ok {
+		if c.Min != nil && number < *c.Min {
+			violations.AppendCheck(path, c.Field.Name, value, CheckKidMin, c.ErrorMsg, fmt.Sprintf("value '%v' is less than min %v", value, *c.Min))
+		}
+		if c.Max != nil && number > *c.Max {
+			violations.AppendCheck(path, c.Field.Name, value, CheckKidMax, c.ErrorMsg, fmt.Sprintf("value '%v' is greater than max %v", value, *c.Max))
+		}
+	}
+	if c.MinLength != nil || c.MaxLength != nil {
+		if length, ok := valueLength(value); ok {
+			if c.MinLength != nil && length < *c.MinLength {
+				violations.AppendCheck(path, c.Field.Name, value, CheckKidLength, c.ErrorMsg, fmt.Sprintf("value length %v is less than min length %v", length, *c.MinLength))
+			}
+			if c.MaxLength != nil && length > *c.MaxLength {
+				violations.AppendCheck(path, c.Field.Name, value, CheckKidLength, c.ErrorMsg, fmt.Sprintf("value length %v exceeds max length %v", length, *c.MaxLength))
+			}
+		}
+	}
+	if c.Pattern != nil {
+		if text, ok := asText(value); ok && !c.Pattern.MatchString(text) {
+			violations.AppendCheck(path, c.Field.Name, value, CheckKidPattern, c.ErrorMsg, fmt.Sprintf("value '%v' does not match pattern %v", value, c.Pattern.String()))
+		}
+	}
+	if len(c.OneOf) > 0 && !c.isOneOf(value) {
+		violations.AppendCheck(path, c.Field.Name, value, CheckKidOneOf, c.ErrorMsg, fmt.Sprintf("value '%v' is not one of: %v", value, strings.Join(c.OneOf, ",")))
+	}
+	for _, comparison := range c.Comparisons {
+		other := fieldValue(comparison.Field, ptr)
+		if other == nil {
+			continue
+		}
+		if result, ok := compare(value, other); ok && !comparison.matches(result) {
+			violations.AppendCheck(path, c.Field.Name, value, CheckKidCompare, c.ErrorMsg,
+				fmt.Sprintf("value '%v' is not %v %v '%v'", value, comparisonNames[comparison.Op], comparison.Field.Name, other))
+		}
+	}
+}
+
+func (c *ValueCheck) isOneOf(value interface{}) bool {
+	text := fmt.Sprintf("%v", value)
+	for _, candidate := range c.OneOf {
+		if candidate == text {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *FieldComparison) matches(result int) bool {
+	switch c.Op {
+	case "eq":
+		return result == 0
+	case "ne":
+		return result != 0
+	case "gt":
+		return result > 0
+	case "gte":
+		return result >= 0
+	case "lt":
+		return result < 0
+	case "lte":
+		return result <= 0
+	}
+	return true
+}
+
+// compare returns -1, 0, 1 if values are comparable numbers, strings or times
+func compare(value, other interface{}) (int, bool) {
+	if left, ok := value.(time.Time); ok {
+		right, ok := other.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return left.Compare(right), true
+	}
+	if left, ok := asFloat(value); ok {
+		right, ok := asFloat(other)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case left < right:
+			return -1, true
+		case left > right:
+			return 1, true
+		}
+		return 0, true
+	}
+	if left, ok := asText(value); ok {
+		right, ok := asText(other)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(left, right), true
+	}
+	return 0, false
+}
+
+func asFloat(value interface{}) (float64, bool) {
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rValue.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rValue.Float(), true
+	}
+	return 0, false
+}
+
+func valueLength(value interface{}) (int, bool) {
+	if text, ok := asText(value); ok {
+		return utf8.RuneCountInString(text), true
+	}
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rValue.Len(), true
+	}
+	return 0, false
+}
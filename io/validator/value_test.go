@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type ValueRecord struct {
+	Id        int        `sqlx:"id,primaryKey"`
+	Age       *int       `sqlx:"age,min=18,max=99"`
+	Name      string     `sqlx:"name,minLength=2,maxLength=5"`
+	Email     string     `sqlx:"email,pattern='^[a-z]+@[a-z]+\\.[a-z]{2$coma3}$'"`
+	Status    string     `sqlx:"status,oneOf=active|inactive,errorMsg=status '$value' is not supported"`
+	StartDate *time.Time `sqlx:"start_date"`
+	EndDate   *time.Time `sqlx:"end_date,gtField=StartDate"`
+}
+
+func TestService_Validate_Values(t *testing.T) {
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+	after := start.Add(time.Hour)
+	var testCases = []struct {
+		description string
+		data        interface{}
+		expected    []*Violation
+	}{
+		{
+			description: "valid",
+			data:        &ValueRecord{Id: 1, Age: intPtr(18), Name: "ab", Email: "a@b.io", Status: "active", StartDate: &start, EndDate: &after},
+		},
+		{
+			description: "nil values are skipped",
+			data:        &ValueRecord{Id: 1, Name: "abc", Email: "a@b.com", Status: "inactive", EndDate: &before},
+		},
+		{
+			description: "violations",
+			data: []*ValueRecord{
+				{Id: 1, Age: intPtr(17), Name: "a", Email: "A@b.io", Status: "deleted", StartDate: &start, EndDate: &before},
+				{Id: 2, Age: intPtr(100), Name: "abcdef", Email: "a@b.info", Status: "active", StartDate: &start, EndDate: &start},
+			},
+			expected: []*Violation{
+				{Location: "values[0].Age", Field: "Age", Value: 17, Message: "value '17' is less than min 18", Check: "min"},
+				{Location: "values[0].Email", Field: "Email", Value: "A@b.io", Message: "value 'A@b.io' does not match pattern ^[a-z]+@[a-z]+\\.[a-z]{2,3}$", Check: "pattern"},
+				{Location: "values[0].EndDate", Field: "EndDate", Value: before, Message: "value '" + before.String() + "' is not greater than StartDate '" + start.String() + "'", Check: "compare"},
+				{Location: "values[0].Name", Field: "Name", Value: "a", Message: "value length 1 is less than min length 2", Check: "length"},
+				{Location: "values[0].Status", Field: "Status", Value: "deleted", Message: "status 'deleted' is not supported", Check: "oneOf"},
+				{Location: "values[1].Age", Field: "Age", Value: 100, Message: "value '100' is greater than max 99", Check: "max"},
+				{Location: "values[1].Email", Field: "Email", Value: "a@b.info", Message: "value 'a@b.info' does not match pattern ^[a-z]+@[a-z]+\\.[a-z]{2,3}$", Check: "pattern"},
+				{Location: "values[1].EndDate", Field: "EndDate", Value: start, Message: "value '" + start.String() + "' is not greater than StartDate '" + start.String() + "'", Check: "compare"},
+				{Location: "values[1].Name", Field: "Name", Value: "abcdef", Message: "value length 6 exceeds max length 5", Check: "length"},
+			},
+		},
+	}
+	srv := New()
+	for _, testCase := range testCases {
+		validation, err := srv.Validate(context.Background(), nil, testCase.data, WithLocation("values"), WithUnique(false))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(testCase.expected) > 0, validation.Failed, testCase.description)
+		assert.EqualValues(t, testCase.expected, validation.Violations, testCase.description)
+	}
+}
+
+func TestNewChecks_InvalidValueTag(t *testing.T) {
+	type invalidMin struct {
+		Age int `sqlx:"age,min=abc"`
+	}
+	type invalidField struct {
+		End int `sqlx:"end,gtField=Start"`
+	}
+	for _, recordType := range []reflect.Type{reflect.TypeOf(invalidMin{}), reflect.TypeOf(invalidField{})} {
+		_, err := NewChecks(recordType, nil)
+		assert.NotNil(t, err, recordType.String())
+	}
+}
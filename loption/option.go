@@ -2,16 +2,20 @@ package loption
 
 import (
 	"database/sql"
+	"github.com/viant/sqlx/io/retry"
 	"github.com/viant/sqlx/option"
 )
 
 type (
 	Options struct {
 		withUpsert    bool
+		withPgxCopy   bool
 		tx            *sql.Tx
 		format        string
 		hint          string
 		commonOptions option.Options
+		retryPolicy   *retry.Policy
+		shardResolver option.ShardResolver
 	}
 
 	Option func(o *Options)
@@ -43,6 +47,21 @@ func WithHint(hint string) Option {
 	}
 }
 
+// WithRetryPolicy sets retry policy re-running service owned transaction on transient failure,
+// policy is ignored when transaction was supplied with WithTransaction or common options
+func WithRetryPolicy(policy *retry.Policy) Option {
+	return func(o *Options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithShardResolver sets shard resolver splitting records by shard
+func WithShardResolver(resolver option.ShardResolver) Option {
+	return func(o *Options) {
+		o.shardResolver = resolver
+	}
+}
+
 func WithCommonOptions(commonOptions option.Options) Option {
 	return func(o *Options) {
 		o.commonOptions = commonOptions
@@ -55,6 +74,19 @@ func WithUpsert() Option {
 	}
 }
 
+// WithPgxCopy enables Postgres binary COPY format and encoded data copy, these are pgx only paths streaming data through
+// dedicated pgx stdlib driver connection, outside of any transaction, thus they can not be combined with a transaction
+func WithPgxCopy() Option {
+	return func(o *Options) {
+		o.withPgxCopy = true
+	}
+}
+
+// GetWithPgxCopy returns true if pgx COPY was enabled
+func (o *Options) GetWithPgxCopy() bool {
+	return o.withPgxCopy
+}
+
 func (o *Options) GetWithUpsert() bool {
 	return o.withUpsert
 }
@@ -74,3 +106,22 @@ func (o *Options) GetHint() string {
 func (o *Options) GetCommonOptions() option.Options {
 	return o.commonOptions
 }
+
+// GetRetryPolicy returns retry policy for service owned transaction, or nil if transaction was supplied
+func (o *Options) GetRetryPolicy() *retry.Policy {
+	if o.tx != nil || o.commonOptions.Tx() != nil {
+		return nil
+	}
+	if o.retryPolicy != nil {
+		return o.retryPolicy
+	}
+	return retry.Owned(o.commonOptions)
+}
+
+// GetShardResolver returns shard resolver or nil
+func (o *Options) GetShardResolver() option.ShardResolver {
+	if o.shardResolver != nil {
+		return o.shardResolver
+	}
+	return o.commonOptions.ShardResolver()
+}
@@ -21,12 +21,15 @@ type Dialect struct {
 	AutoincrementFunc string
 	CanLastInsertID   bool
 	CanReturning      bool //Postgress supports Returning Data From Modified Rows in one statement
+	CanRowValueIn     bool //supports row value comparison: (a, b) IN ((?, ?), (?, ?))
 	QuoteCharacter    byte
 	// TODO: check if column has a space or exist in keywords in this case use quote if keyword is specified
 	// i.e. normalized column on the dialect
 	Keywords                  map[string]bool
 	DefaultPresetIDStrategy   dialect.PresetIDStrategy
 	SpecialKeywordEscapeQuote byte
+	Explain                   string // statement prefix returning execution plan, empty if not supported
+	SkipLocked                bool   // supports SELECT ... FOR UPDATE SKIP LOCKED
 }
 
 //Dialects represents dialects
@@ -10,4 +10,5 @@ const (
 	PresetIDWithTransientTransaction = PresetIDStrategy("transient")
 	PresetIDWithUDFSequence          = PresetIDStrategy("udf")
 	PresetIDWithMax                  = PresetIDStrategy("maxid")
+	PresetIDWithSequenceRange        = PresetIDStrategy("sequence")
 )
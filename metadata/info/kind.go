@@ -75,6 +75,10 @@ const (
 	KindLockGet
 	// KindLockRelease defines lock release kind
 	KindLockRelease
+	//KindCheckConstraints defines table check constraints kind
+	KindCheckConstraints
+	//KindReplicationLag defines replica replication lag kind
+	KindReplicationLag
 	//KindReserved defines reserved kind
 	KindReserved
 )
@@ -127,6 +131,10 @@ func (k Kind) String() string {
 		return "KindLockGet"
 	case KindLockRelease:
 		return "KindLockRelease"
+	case KindCheckConstraints:
+		return "KindCheckConstraints"
+	case KindReplicationLag:
+		return "KindReplicationLag"
 	}
 	return fmt.Sprintf("undefined kind: %v", int(k))
 }
@@ -180,6 +188,8 @@ func (k Kind) Criteria() []string {
 		return []string{Catalog, Schema, Table}
 	case KindLockRelease:
 		return []string{Catalog, Schema, Table}
+	case KindCheckConstraints:
+		return []string{Catalog, Schema, Table}
 	}
 	return emptyCriteria
 }
@@ -0,0 +1,66 @@
+// Package sequence provides sequence range reservation shared by products fetching NEXTVAL over generated rows
+package sequence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxAttempts represents max number of attempts to reserve continuous values range
+const maxAttempts = 3
+
+// ReserveRange runs SQL returning record count sequence next values and returns first value of continuous range,
+// since concurrent sessions or session cache boundary may split fetched values, it retries (gaps are fine for sequences)
+func ReserveRange(ctx context.Context, db *sql.DB, SQL string, name string, recordCount, incrementBy int64) (int64, error) {
+	for i := 0; i < maxAttempts; i++ {
+		values, err := fetchValues(ctx, db, SQL)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reserve %v values from sequence %v: %w", recordCount, name, err)
+		}
+		if int64(len(values)) != recordCount {
+			return 0, fmt.Errorf("failed to reserve %v values from sequence %v, had: %v", recordCount, name, len(values))
+		}
+		if first, ok := continuousRange(values, incrementBy); ok {
+			return first, nil
+		}
+	}
+	return 0, fmt.Errorf("failed to reserve continuous range of %v values from sequence %v", recordCount, name)
+}
+
+// Escape escapes single quotes in SQL string literal
+func Escape(name string) string {
+	return strings.ReplaceAll(name, "'", "''")
+}
+
+func fetchValues(ctx context.Context, db *sql.DB, SQL string) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, SQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []int64
+	for rows.Next() {
+		var value int64
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, rows.Err()
+}
+
+func continuousRange(values []int64, incrementBy int64) (int64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	for i := 1; i < len(values); i++ {
+		if values[i]-values[i-1] != incrementBy {
+			return 0, false
+		}
+	}
+	return values[0], true
+}
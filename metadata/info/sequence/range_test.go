@@ -0,0 +1,49 @@
+package sequence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContinuousRange(t *testing.T) {
+	var testCases = []struct {
+		description string
+		values      []int64
+		incrementBy int64
+		expect      int64
+		expectOk    bool
+	}{
+		{
+			description: "unordered continuous values",
+			values:      []int64{12, 10, 11},
+			incrementBy: 1,
+			expect:      10,
+			expectOk:    true,
+		},
+		{
+			description: "continuous values with increment",
+			values:      []int64{5, 15, 10},
+			incrementBy: 5,
+			expect:      5,
+			expectOk:    true,
+		},
+		{
+			description: "interleaved values",
+			values:      []int64{10, 11, 14},
+			incrementBy: 1,
+		},
+		{
+			description: "no values",
+			incrementBy: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		first, ok := continuousRange(testCase.values, testCase.incrementBy)
+		assert.EqualValues(t, testCase.expectOk, ok, testCase.description)
+		assert.EqualValues(t, testCase.expect, first, testCase.description)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	assert.EqualValues(t, "O''BRIEN_SEQ", Escape("O'BRIEN_SEQ"))
+}
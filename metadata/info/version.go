@@ -0,0 +1,51 @@
+package info
+
+import (
+	"context"
+	"database/sql"
+	"github.com/viant/sqlx/metadata/database"
+)
+
+// minVersionHandler stops query with empty result when detected product version is older than required
+type minVersionHandler struct {
+	major, minor, release int
+}
+
+// Handle returns false (empty result) when product version is older than required
+func (h *minVersionHandler) Handle(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error) {
+	product := productOption(options)
+	if product == nil || product.Major == 0 { //unknown version
+		return true, nil
+	}
+	return !IsOlder(product, h.major, h.minor, h.release), nil
+}
+
+// CanUse returns true
+func (h *minVersionHandler) CanUse(options ...interface{}) bool {
+	return true
+}
+
+// MinVersion returns pre handler skipping query with empty result on product version older than supplied one
+func MinVersion(major, minor, release int) Handler {
+	return &minVersionHandler{major: major, minor: minor, release: release}
+}
+
+// IsOlder returns true if product version is older than supplied version
+func IsOlder(product *database.Product, major, minor, release int) bool {
+	if product.Major != major {
+		return product.Major < major
+	}
+	if product.Minor != minor {
+		return product.Minor < minor
+	}
+	return product.Release < release
+}
+
+func productOption(options []interface{}) *database.Product {
+	for _, candidate := range options {
+		if product, ok := candidate.(*database.Product); ok {
+			return product
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package info
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/database"
+	"testing"
+)
+
+func TestMinVersion(t *testing.T) {
+	var testCases = []struct {
+		description string
+		product     *database.Product
+		expect      bool
+	}{
+		{description: "older release", product: &database.Product{Name: "MySQL", Major: 8, Minor: 0, Release: 15}, expect: false},
+		{description: "same release", product: &database.Product{Name: "MySQL", Major: 8, Minor: 0, Release: 16}, expect: true},
+		{description: "newer minor", product: &database.Product{Name: "MySQL", Major: 8, Minor: 4}, expect: true},
+		{description: "older major", product: &database.Product{Name: "MySQL", Major: 5, Minor: 7, Release: 44}, expect: false},
+		{description: "unknown version", product: &database.Product{Name: "MySQL"}, expect: true},
+		{description: "no product", expect: true},
+	}
+	handler := MinVersion(8, 0, 16)
+	for _, testCase := range testCases {
+		var options []interface{}
+		if testCase.product != nil {
+			options = append(options, testCase.product)
+		}
+		doNext, err := handler.Handle(context.Background(), nil, nil, options...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, doNext, testCase.description)
+	}
+}
@@ -0,0 +1,21 @@
+package bigquery
+
+import (
+	"github.com/viant/sqlx/io/errx"
+	"strings"
+)
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "could not serialize access"):
+		return &errx.Classification{Kind: errx.ErrSerialization}
+	case strings.Contains(message, "connection reset"), strings.Contains(message, "broken pipe"):
+		return &errx.Classification{Kind: errx.ErrConnectionLost}
+	}
+	return nil
+}
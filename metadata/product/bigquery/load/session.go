@@ -64,6 +64,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	if err != nil {
 		return nil, err
 	}
+	defer dataReader.Close()
 	readerID := uuid.New().String()
 	err = vBigquery.Register(readerID, dataReader)
 	if err != nil {
@@ -76,15 +77,45 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	return db.ExecContext(ctx, SQL)
 }
 
-func (s *Session) getReader(loadFormat string, data interface{}) (goIo.Reader, error) {
+func (s *Session) getReader(loadFormat string, data interface{}) (goIo.ReadCloser, error) {
+	switch actual := data.(type) {
+	case *io.EncodedStream: //encoded reader is owned by caller
+		return goIo.NopCloser(actual.Reader), nil
+	case *io.RecordStream:
+		return s.getStreamReader(loadFormat, actual)
+	}
+
+	var reader goIo.Reader
+	var err error
 	switch strings.ToUpper(loadFormat) {
 	case formatCSV:
-		reader, _, err := readerCsv.NewReader(data, loadConfig)
-		return reader, err
+		reader, _, err = readerCsv.NewReader(data, loadConfig)
+	case formatJSON:
+		reader, err = readerJson.NewReader(data)
+	case formatPARQUET:
+		reader, err = readerParquet.NewReader(data)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s, supported[%s|%s|%s]", loadFormat, formatCSV, formatJSON, formatPARQUET)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return goIo.NopCloser(reader), nil
+}
+
+// getStreamReader returns streamed records reader, json and parquet readers encode records with pipe goroutine released on Close
+func (s *Session) getStreamReader(loadFormat string, stream *io.RecordStream) (goIo.ReadCloser, error) {
+	switch strings.ToUpper(loadFormat) {
+	case formatCSV:
+		reader, _, err := readerCsv.NewStreamReader(stream, loadConfig)
+		if err != nil {
+			return nil, err
+		}
+		return goIo.NopCloser(reader), nil
 	case formatJSON:
-		return readerJson.NewReader(data)
+		return readerJson.NewStreamReader(stream), nil
 	case formatPARQUET:
-		return readerParquet.NewReader(data)
+		return readerParquet.NewStreamReader(stream), nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s, supported[%s|%s|%s]", loadFormat, formatCSV, formatJSON, formatPARQUET)
 	}
@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"github.com/viant/sqlx/io/errx"
+	"regexp"
+	"strings"
+)
+
+var errorCode = regexp.MustCompile(`Error (\d+)(?: \(([0-9A-Z]{5})\))?:`)
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	message := err.Error()
+	if strings.Contains(message, "invalid connection") {
+		return &errx.Classification{Kind: errx.ErrConnectionLost}
+	}
+	match := errorCode.FindStringSubmatch(message)
+	if len(match) == 0 {
+		return nil
+	}
+	result := &errx.Classification{Code: match[1]}
+	switch match[1] {
+	case "1062", "1586":
+		result.Kind = errx.ErrDuplicateKey
+		result.Constraint = errx.QuotedName(message, "for key")
+		if index := strings.LastIndex(result.Constraint, "."); index != -1 { //MySQL 8 reports table.key
+			result.Constraint = result.Constraint[index+1:]
+		}
+	case "1451", "1452", "1216", "1217":
+		result.Kind = errx.ErrForeignKey
+		result.Constraint = errx.QuotedName(message, ", CONSTRAINT")
+	case "1048", "1364":
+		result.Kind = errx.ErrNotNull
+		result.Column = errx.QuotedName(message, "Field")
+		if result.Column == "" {
+			result.Column = errx.QuotedName(message, "Column")
+		}
+	case "3819":
+		result.Kind = errx.ErrCheck
+		result.Constraint = errx.QuotedName(message, "Check constraint")
+	case "1213":
+		result.Kind = errx.ErrDeadlock
+	case "1205":
+		result.Kind = errx.ErrLockTimeout
+	case "2006", "2013":
+		result.Kind = errx.ErrConnectionLost
+	default:
+		if result.Kind = errx.ClassifySQLState(match[2]); result.Kind == nil {
+			return nil
+		}
+	}
+	return result
+}
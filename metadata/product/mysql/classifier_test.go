@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/errx"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	var testCases = []struct {
+		description string
+		message     string
+		expect      *errx.Classification
+	}{
+		{
+			description: "duplicate entry",
+			message:     "Error 1062 (23000): Duplicate entry 'a@b.c' for key 'users.uk_email'",
+			expect:      &errx.Classification{Kind: errx.ErrDuplicateKey, Code: "1062", Constraint: "uk_email"},
+		},
+		{
+			description: "foreign key",
+			message:     "Error 1452: Cannot add or update a child row: a foreign key constraint fails (`db`.`orders`, CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))",
+			expect:      &errx.Classification{Kind: errx.ErrForeignKey, Code: "1452", Constraint: "fk_user"},
+		},
+		{
+			description: "not null",
+			message:     "Error 1048 (23000): Column 'name' cannot be null",
+			expect:      &errx.Classification{Kind: errx.ErrNotNull, Code: "1048", Column: "name"},
+		},
+		{
+			description: "deadlock",
+			message:     "Error 1213 (40001): Deadlock found when trying to get lock; try restarting transaction",
+			expect:      &errx.Classification{Kind: errx.ErrDeadlock, Code: "1213"},
+		},
+		{
+			description: "unrecognized",
+			message:     "Error 1064 (42000): You have an error in your SQL syntax",
+		},
+	}
+	for _, testCase := range testCases {
+		actual := classify(errors.New(testCase.message))
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
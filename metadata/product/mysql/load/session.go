@@ -11,6 +11,7 @@ import (
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 	goIo "io"
+	"reflect"
 )
 
 var mysqlLoadConfig = &csv.Config{
@@ -37,9 +38,10 @@ func NewSession(dialect *info.Dialect) io.LoadExecutor {
 }
 
 // Exec inserts given data to database using "LOAD DATA LOCAL INFILE"
+// data can be a slice, *io.RecordStream or *io.EncodedStream with data encoded with MySQL load config
 // note: local_infile=1 must be enabled on database
 func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...loption.Option) (sql.Result, error) {
-	dataReader, dataType, err := csv.NewReader(data, mysqlLoadConfig)
+	dataReader, csvReader, dataType, err := newReader(data)
 	if err != nil {
 		return nil, err
 	}
@@ -49,8 +51,9 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		return nil, err
 	}
 
+	counter := &io.CountingReader{Reader: dataReader}
 	readerResolver := func() goIo.Reader {
-		return dataReader
+		return counter
 	}
 
 	readerID := uuid.New().String()
@@ -69,6 +72,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		result.Result, err = db.ExecContext(ctx, SQL)
 	}
 	err = s.end(err)
+	result.Bytes = counter.Count
 
 	if err != nil {
 		return result, err
@@ -76,14 +80,29 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 
 	// Omitting bug: 0 affected rows
 	result.Rows, err = result.Result.RowsAffected()
-	if err == nil && result.Rows == 0 && dataReader.ItemCount() > 0 {
-		result.Rows = int64(dataReader.ItemCount()) //assigning explicitly value
+	if err == nil && result.Rows == 0 && csvReader != nil && csvReader.ItemCount() > 0 {
+		result.Rows = int64(csvReader.ItemCount()) //assigning explicitly value
 		return result, nil
 	}
 
 	return result, err
 }
 
+func newReader(data interface{}) (goIo.Reader, *csv.Reader, reflect.Type, error) {
+	switch actual := data.(type) {
+	case *io.EncodedStream:
+		return actual.Reader, nil, actual.StructType(), nil
+	case *io.RecordStream:
+		reader, dataType, err := csv.NewStreamReader(actual, mysqlLoadConfig)
+		return reader, reader, dataType, err
+	}
+	reader, dataType, err := csv.NewReader(data, mysqlLoadConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return reader, reader, dataType, nil
+}
+
 func (s *Session) begin(ctx context.Context, db *sql.DB, options []loption.Option) error {
 	var err error
 	loadOpts := loption.NewOptions(options...)
@@ -24,6 +24,11 @@ var mySQL57 = database.Product{
 	Minor: 7,
 }
 
+var mySQL8 = database.Product{
+	Name:  product,
+	Major: 8,
+}
+
 // MySQL5 return MySQL 5.x product
 func MySQL5() *database.Product {
 	return &mySQL5
@@ -79,6 +84,7 @@ COLUMN_NAME,
 ORDINAL_POSITION,
 COLUMN_COMMENT,
 DATA_TYPE,
+COLUMN_TYPE,
 CHARACTER_MAXIMUM_LENGTH,
 NUMERIC_PRECISION,
 NUMERIC_SCALE,
@@ -188,6 +194,22 @@ WHERE s.CONSTRAINT_TYPE = 'FOREIGN KEY'
 			info.NewCriterion(info.Table, "c.TABLE_NAME"),
 		),
 
+		info.NewQuery(info.KindCheckConstraints, `SELECT 
+c.CONSTRAINT_NAME,
+'' CONSTRAINT_CATALOG,
+c.CONSTRAINT_SCHEMA,
+s.TABLE_NAME,
+c.CHECK_CLAUSE
+FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS c
+JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS s ON s.CONSTRAINT_SCHEMA = c.CONSTRAINT_SCHEMA 
+	AND s.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+WHERE s.CONSTRAINT_TYPE = 'CHECK' AND s.ENFORCED = 'YES'
+`, mySQL8,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "c.CONSTRAINT_SCHEMA"),
+			info.NewCriterion(info.Table, "s.TABLE_NAME"),
+		).OnPre(info.MinVersion(8, 0, 16)), //CHECK_CONSTRAINTS and ENFORCED require MySQL 8.0.16+
+
 		info.NewQuery(info.KindSession, `SELECT 
 CAST(ID AS CHAR) AS PID,
 CAST(USER AS CHAR) AS USER_NAME,
@@ -240,6 +262,15 @@ RELEASE_LOCK('$Args[0].$Args[1].$Args[2]')  AS SUCCESS`,
 			info.NewCriterion(info.Schema, ""),
 			info.NewCriterion(info.Table, ""),
 		),
+
+		info.NewQuery(info.KindReplicationLag, `SELECT COALESCE(MAX(CASE WHEN APPLYING_TRANSACTION <> '' 
+THEN TIMESTAMPDIFF(MICROSECOND, APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP, NOW(6)) / 1000000 
+ELSE 0 END), 0) AS LAG_SECONDS,
+(SELECT COUNT(*) FROM performance_schema.replication_applier_status WHERE SERVICE_STATE <> 'ON') +
+(SELECT COUNT(*) FROM performance_schema.replication_connection_status WHERE SERVICE_STATE = 'OFF') > 0 AS STOPPED
+FROM performance_schema.replication_applier_status_by_worker`,
+			mySQL8,
+		).OnPre(&replicaStatus{}), //MySQL 5.x uses SHOW SLAVE STATUS
 	)
 
 	if err != nil {
@@ -257,9 +288,12 @@ RELEASE_LOCK('$Args[0].$Args[1].$Args[2]')  AS SUCCESS`,
 		QuoteCharacter:            '\'',
 		CanAutoincrement:          true,
 		CanLastInsertID:           true, // in reality true but multi-insert gives us the id from the first row, not the last one
+		CanRowValueIn:             true,
 		// TODO: provide real autoincrement function
 		AutoincrementFunc:       "autoincrement",
 		DefaultPresetIDStrategy: dialect.PresetIDWithTransientTransaction,
+		Explain:                 "EXPLAIN FORMAT=JSON ",
+		SkipLocked:              true, //MySQL 8.0.1+, outbox poller detects older servers
 	})
 
 }
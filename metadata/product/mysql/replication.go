@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/sqlx/option"
+	"strconv"
+	"strings"
+)
+
+// replicaStatus represents MySQL 5.x replication lag handler using "SHOW SLAVE STATUS",
+// performance_schema replication_applier_status_by_worker commit timestamps require MySQL 8.0+
+type replicaStatus struct{}
+
+// CanUse returns true for detected MySQL version older than 8
+func (r *replicaStatus) CanUse(options ...interface{}) bool {
+	product := option.AsOptions(options).Product()
+	return product != nil && product.Major > 0 && product.Major < 8
+}
+
+// Handle reads replica status into *sink.Replication target
+func (r *replicaStatus) Handle(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error) {
+	replication, ok := target.(*sink.Replication)
+	if !ok {
+		return false, fmt.Errorf("invalid replication target: expected %T, but had %T", replication, target)
+	}
+	rows, err := db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return false, err
+	}
+	if !rows.Next() { //not a replica
+		return false, rows.Err()
+	}
+	values := make([]sql.RawBytes, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err = rows.Scan(pointers...); err != nil {
+		return false, err
+	}
+	*replication = asReplication(columns, values)
+	return false, rows.Err()
+}
+
+// asReplication converts "SHOW SLAVE STATUS" row into replication status, stopped IO or SQL thread marks replica as stopped
+func asReplication(columns []string, values []sql.RawBytes) sink.Replication {
+	result := sink.Replication{}
+	for i, column := range columns {
+		value := string(values[i])
+		switch column {
+		case "Seconds_Behind_Master", "Seconds_Behind_Source":
+			if value == "" { //NULL when SQL thread is not running
+				result.Stopped = true
+				continue
+			}
+			result.LagSeconds, _ = strconv.ParseFloat(value, 64)
+		case "Slave_IO_Running", "Replica_IO_Running", "Slave_SQL_Running", "Replica_SQL_Running":
+			if strings.EqualFold(value, "No") {
+				result.Stopped = true
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/database"
+	"github.com/viant/sqlx/metadata/sink"
+	"testing"
+)
+
+func TestAsReplication(t *testing.T) {
+	columns := []string{"Slave_IO_Running", "Slave_SQL_Running", "Seconds_Behind_Master"}
+	var testCases = []struct {
+		description string
+		columns     []string
+		values      []string
+		expect      sink.Replication
+	}{
+		{description: "running replica", columns: columns, values: []string{"Yes", "Yes", "3"}, expect: sink.Replication{LagSeconds: 3}},
+		{description: "stopped SQL thread", columns: columns, values: []string{"Yes", "No", ""}, expect: sink.Replication{Stopped: true}},
+		{description: "stopped IO thread", columns: columns, values: []string{"No", "Yes", "0"}, expect: sink.Replication{Stopped: true}},
+		{description: "connecting IO thread", columns: columns, values: []string{"Connecting", "Yes", "1"}, expect: sink.Replication{LagSeconds: 1}},
+		{description: "replica naming", columns: []string{"Replica_IO_Running", "Replica_SQL_Running", "Seconds_Behind_Source"},
+			values: []string{"Yes", "No", ""}, expect: sink.Replication{Stopped: true}},
+	}
+	for _, testCase := range testCases {
+		values := make([]sql.RawBytes, len(testCase.values))
+		for i, value := range testCase.values {
+			values[i] = sql.RawBytes(value)
+		}
+		assert.EqualValues(t, testCase.expect, asReplication(testCase.columns, values), testCase.description)
+	}
+}
+
+func TestReplicaStatus_CanUse(t *testing.T) {
+	var testCases = []struct {
+		description string
+		product     *database.Product
+		expect      bool
+	}{
+		{description: "MySQL 5.7", product: &database.Product{Name: product, Major: 5, Minor: 7}, expect: true},
+		{description: "MySQL 8.0", product: &database.Product{Name: product, Major: 8}},
+		{description: "unknown version", product: &database.Product{Name: product}},
+		{description: "no product"},
+	}
+	for _, testCase := range testCases {
+		var options []interface{}
+		if testCase.product != nil {
+			options = append(options, testCase.product)
+		}
+		assert.EqualValues(t, testCase.expect, (&replicaStatus{}).CanUse(options...), testCase.description)
+	}
+}
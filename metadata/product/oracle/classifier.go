@@ -0,0 +1,77 @@
+package oracle
+
+import (
+	"github.com/viant/sqlx/io/errx"
+	"regexp"
+	"strings"
+)
+
+var errorCode = regexp.MustCompile(`ORA-(\d{5})`)
+
+var constraintName = regexp.MustCompile(`constraint \(([^)]+)\)`)
+
+var nullTarget = regexp.MustCompile(`NULL into \(([^)]+)\)`)
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	message := err.Error()
+	match := errorCode.FindStringSubmatch(message)
+	if len(match) == 0 {
+		return nil
+	}
+	result := &errx.Classification{Code: "ORA-" + match[1]}
+	switch match[1] {
+	case "00001":
+		result.Kind = errx.ErrDuplicateKey
+		result.Constraint = constraint(message)
+	case "02291", "02292":
+		result.Kind = errx.ErrForeignKey
+		result.Constraint = constraint(message)
+	case "01400", "01407":
+		result.Kind = errx.ErrNotNull
+		result.Table, result.Column = nullColumn(message)
+	case "02290":
+		result.Kind = errx.ErrCheck
+		result.Constraint = constraint(message)
+	case "00060":
+		result.Kind = errx.ErrDeadlock
+	case "08177":
+		result.Kind = errx.ErrSerialization
+	case "00054", "30006":
+		result.Kind = errx.ErrLockTimeout
+	case "03113", "03114", "03135", "12537":
+		result.Kind = errx.ErrConnectionLost
+	default:
+		return nil
+	}
+	return result
+}
+
+// constraint returns constraint name from "... constraint (SCHEMA.NAME) ..." message
+func constraint(message string) string {
+	match := constraintName.FindStringSubmatch(message)
+	if len(match) == 0 {
+		return ""
+	}
+	name := match[1]
+	if index := strings.LastIndex(name, "."); index != -1 {
+		name = name[index+1:]
+	}
+	return name
+}
+
+// nullColumn returns table and column from `cannot insert NULL into ("SCHEMA"."TABLE"."COLUMN")` message
+func nullColumn(message string) (string, string) {
+	match := nullTarget.FindStringSubmatch(message)
+	if len(match) == 0 {
+		return "", ""
+	}
+	parts := strings.Split(strings.ReplaceAll(match[1], `"`, ""), ".")
+	if len(parts) < 2 {
+		return "", parts[0]
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
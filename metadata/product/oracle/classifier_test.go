@@ -0,0 +1,45 @@
+package oracle
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/errx"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	var testCases = []struct {
+		description string
+		message     string
+		expect      *errx.Classification
+	}{
+		{
+			description: "unique constraint",
+			message:     "ORA-00001: unique constraint (APP.UK_EMAIL) violated",
+			expect:      &errx.Classification{Kind: errx.ErrDuplicateKey, Code: "ORA-00001", Constraint: "UK_EMAIL"},
+		},
+		{
+			description: "parent key not found",
+			message:     "ORA-02291: integrity constraint (APP.FK_USER) violated - parent key not found",
+			expect:      &errx.Classification{Kind: errx.ErrForeignKey, Code: "ORA-02291", Constraint: "FK_USER"},
+		},
+		{
+			description: "not null",
+			message:     `ORA-01400: cannot insert NULL into ("APP"."USERS"."NAME")`,
+			expect:      &errx.Classification{Kind: errx.ErrNotNull, Code: "ORA-01400", Table: "USERS", Column: "NAME"},
+		},
+		{
+			description: "serialization",
+			message:     "ORA-08177: can't serialize access for this transaction",
+			expect:      &errx.Classification{Kind: errx.ErrSerialization, Code: "ORA-08177"},
+		},
+		{
+			description: "unrecognized",
+			message:     "ORA-00942: table or view does not exist",
+		},
+	}
+	for _, testCase := range testCases {
+		actual := classify(errors.New(testCase.message))
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
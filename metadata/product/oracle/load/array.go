@@ -0,0 +1,129 @@
+package load
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// arrayOf converts column values into typed slice used by driver array binding,
+// columns with NULL values are converted into slice of sql.Null* type
+func arrayOf(values []interface{}) (interface{}, error) {
+	var elemType reflect.Type
+	hasNull := false
+	for i, value := range values {
+		value, err := normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+		if value == nil {
+			hasNull = true
+			continue
+		}
+		if elemType == nil {
+			elemType = reflect.TypeOf(value)
+		}
+	}
+
+	if elemType == nil {
+		return make([]sql.NullString, len(values)), nil
+	}
+	if !hasNull {
+		result := reflect.MakeSlice(reflect.SliceOf(elemType), 0, len(values))
+		for _, value := range values {
+			rValue := reflect.ValueOf(value)
+			if rValue.Type() != elemType {
+				if rValue.Kind() != elemType.Kind() || !rValue.Type().ConvertibleTo(elemType) {
+					return nil, fmt.Errorf("inconsistent column value types: %v, %v", elemType, rValue.Type())
+				}
+				rValue = rValue.Convert(elemType)
+			}
+			result = reflect.Append(result, rValue)
+		}
+		return result.Interface(), nil
+	}
+	return nullArrayOf(elemType, values)
+}
+
+func nullArrayOf(elemType reflect.Type, values []interface{}) (interface{}, error) {
+	if elemType == timeType {
+		result := make([]sql.NullTime, len(values))
+		for i, value := range values {
+			if value != nil {
+				result[i] = sql.NullTime{Time: value.(time.Time), Valid: true}
+			}
+		}
+		return result, nil
+	}
+
+	switch elemType.Kind() {
+	case reflect.String:
+		result := make([]sql.NullString, len(values))
+		for i, value := range values {
+			if value != nil {
+				result[i] = sql.NullString{String: reflect.ValueOf(value).String(), Valid: true}
+			}
+		}
+		return result, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result := make([]sql.NullInt64, len(values))
+		for i, value := range values {
+			if value != nil {
+				result[i] = sql.NullInt64{Int64: reflect.ValueOf(value).Int(), Valid: true}
+			}
+		}
+		return result, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result := make([]sql.NullInt64, len(values))
+		for i, value := range values {
+			if value != nil {
+				result[i] = sql.NullInt64{Int64: int64(reflect.ValueOf(value).Uint()), Valid: true}
+			}
+		}
+		return result, nil
+	case reflect.Float32, reflect.Float64:
+		result := make([]sql.NullFloat64, len(values))
+		for i, value := range values {
+			if value != nil {
+				result[i] = sql.NullFloat64{Float64: reflect.ValueOf(value).Float(), Valid: true}
+			}
+		}
+		return result, nil
+	case reflect.Bool:
+		result := make([]sql.NullBool, len(values))
+		for i, value := range values {
+			if value != nil {
+				result[i] = sql.NullBool{Bool: reflect.ValueOf(value).Bool(), Valid: true}
+			}
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported nullable array type: %v", elemType)
+}
+
+// normalize dereferences pointers and resolves driver.Valuer, it returns nil for NULL
+func normalize(value interface{}) (interface{}, error) {
+	for value != nil {
+		if valuer, ok := value.(driver.Valuer); ok {
+			rValue := reflect.ValueOf(value)
+			if rValue.Kind() == reflect.Ptr && rValue.IsNil() {
+				return nil, nil
+			}
+			return valuer.Value()
+		}
+		rValue := reflect.ValueOf(value)
+		if rValue.Kind() != reflect.Ptr {
+			return value, nil
+		}
+		if rValue.IsNil() {
+			return nil, nil
+		}
+		value = rValue.Elem().Interface()
+	}
+	return nil, nil
+}
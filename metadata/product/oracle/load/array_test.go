@@ -0,0 +1,39 @@
+package load
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArrayOf(t *testing.T) {
+	name := "B"
+	var nilName *string
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var testCases = []struct {
+		description string
+		values      []interface{}
+		expected    interface{}
+		hasError    bool
+	}{
+		{description: "ints", values: []interface{}{1, 2}, expected: []int{1, 2}},
+		{description: "pointers", values: []interface{}{&name, &name}, expected: []string{"B", "B"}},
+		{description: "nullable strings", values: []interface{}{"A", nilName}, expected: []sql.NullString{{String: "A", Valid: true}, {}}},
+		{description: "nullable ints", values: []interface{}{nil, int64(3)}, expected: []sql.NullInt64{{}, {Int64: 3, Valid: true}}},
+		{description: "nullable time", values: []interface{}{&ts, nil}, expected: []sql.NullTime{{Time: ts, Valid: true}, {}}},
+		{description: "valuer", values: []interface{}{sql.NullString{String: "A", Valid: true}, sql.NullString{}}, expected: []sql.NullString{{String: "A", Valid: true}, {}}},
+		{description: "all nulls", values: []interface{}{nil, nil}, expected: []sql.NullString{{}, {}}},
+		{description: "inconsistent types", values: []interface{}{"A", 1}, hasError: true},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := arrayOf(testCase.values)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expected, actual, testCase.description)
+	}
+}
@@ -0,0 +1,10 @@
+package load
+
+import (
+	"github.com/viant/sqlx/metadata/product/oracle"
+	"github.com/viant/sqlx/metadata/registry"
+)
+
+func init() {
+	registry.RegisterLoad(NewSession, oracle.Oracle().Name)
+}
@@ -0,0 +1,160 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/loption"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	goIo "io"
+)
+
+// defaultBatchSize represents default number of rows bound with one statement execution
+const defaultBatchSize = 1000
+
+// BulkOptions represents Oracle load options passed with loption.WithHint as JSON
+type BulkOptions struct {
+	BatchSize int
+}
+
+// Session represents Oracle load session
+type Session struct {
+	*io.Transaction
+	dialect *info.Dialect
+}
+
+// NewSession returns new Oracle load session
+func NewSession(dialect *info.Dialect) io.LoadExecutor {
+	return &Session{
+		dialect: dialect,
+	}
+}
+
+// Exec inserts data to table using array binding: each statement execution binds column slices with up to BatchSize rows,
+// with loption.WithUpsert rows are merged by primary key columns, array binding has to be supported by driver (i.e. godror, go-ora)
+func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...loption.Option) (sql.Result, error) {
+	if _, ok := data.(*io.EncodedStream); ok {
+		return nil, fmt.Errorf("unsupported load data: %T, oracle load requires records", data)
+	}
+
+	loadOpts := loption.NewOptions(options...)
+	bulkOptions := &BulkOptions{}
+	if hint := loadOpts.GetHint(); hint != "" {
+		if err := json.Unmarshal([]byte(hint), bulkOptions); err != nil {
+			return nil, fmt.Errorf("invalid oracle load hint: %w", err)
+		}
+	}
+	if bulkOptions.BatchSize <= 0 {
+		bulkOptions.BatchSize = defaultBatchSize
+	}
+
+	stream, err := io.StreamOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, binder, err := io.StructColumnMapper(stream.StructType(), loadOpts.GetCommonOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("failed to load %v: no columns were mapped for %v", tableName, stream.StructType())
+	}
+
+	var keys []string
+	for _, column := range columns {
+		if tag := column.Tag(); tag != nil && (tag.PrimaryKey || tag.Autoincrement) {
+			keys = append(keys, column.Name())
+		}
+	}
+	SQL, err := BuildSQL(tableName, io.Columns(columns).Names(), keys, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.begin(ctx, db, loadOpts); err != nil {
+		return nil, err
+	}
+
+	result, err := s.load(ctx, stream, binder, len(columns), SQL, bulkOptions.BatchSize)
+	return result, s.end(err)
+}
+
+func (s *Session) load(ctx context.Context, stream *io.RecordStream, binder io.PlaceholderBinder, columnCount int, SQL string, batchSize int) (sql.Result, error) {
+	stmt, err := s.Transaction.PrepareContext(ctx, SQL)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	result := &io.QueryResult{}
+	columnValues := make([][]interface{}, columnCount)
+	params := make([]interface{}, columnCount)
+	rows := 0
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		binder(record, params, 0, columnCount)
+		for i, param := range params {
+			columnValues[i] = append(columnValues[i], param)
+		}
+		if rows++; rows < batchSize {
+			continue
+		}
+		if err = s.exec(ctx, stmt, columnValues, result); err != nil {
+			return nil, err
+		}
+		rows = 0
+	}
+	if rows == 0 {
+		return result, nil
+	}
+	return result, s.exec(ctx, stmt, columnValues, result)
+}
+
+func (s *Session) exec(ctx context.Context, stmt *sql.Stmt, columnValues [][]interface{}, result *io.QueryResult) error {
+	args := make([]interface{}, len(columnValues))
+	for i, values := range columnValues {
+		array, err := arrayOf(values)
+		if err != nil {
+			return fmt.Errorf("failed to bind column %v: %w", i+1, err)
+		}
+		args[i] = array
+		columnValues[i] = values[:0]
+	}
+	exec, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := exec.RowsAffected()
+	result.Rows += affected
+	return err
+}
+
+func (s *Session) begin(ctx context.Context, db *sql.DB, loadOpts *loption.Options) error {
+	var opts []option.Option
+	if tx := loadOpts.GetTransaction(); tx != nil {
+		opts = append(opts, tx)
+	}
+	var err error
+	s.Transaction, err = io.TransactionFor(ctx, s.dialect, db, opts)
+	return err
+}
+
+func (s *Session) end(err error) error {
+	if s.Transaction == nil {
+		return err
+	}
+	if err != nil {
+		return s.Transaction.RollbackWithErr(err)
+	}
+	return s.Transaction.Commit()
+}
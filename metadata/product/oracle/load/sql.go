@@ -0,0 +1,93 @@
+package load
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/loption"
+	"strconv"
+	"strings"
+)
+
+// BuildSQL builds "INSERT" statement with positional binds, with loption.WithUpsert it builds "MERGE" statement matching rows by keys
+func BuildSQL(tableName string, columns []string, keys []string, options ...loption.Option) (string, error) {
+	opts := loption.NewOptions(options...)
+	if !opts.GetWithUpsert() {
+		return buildInsertSQL(tableName, columns), nil
+	}
+	if len(keys) == 0 {
+		return "", fmt.Errorf("failed to build %v upsert: no key columns", tableName)
+	}
+	return buildMergeSQL(tableName, columns, keys), nil
+}
+
+func buildInsertSQL(tableName string, columns []string) string {
+	sb := strings.Builder{}
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(tableName)
+	sb.WriteString("(")
+	sb.WriteString(strings.Join(columns, ","))
+	sb.WriteString(") VALUES (")
+	for i := range columns {
+		if i != 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(":")
+		sb.WriteString(strconv.Itoa(i + 1))
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func buildMergeSQL(tableName string, columns []string, keys []string) string {
+	isKey := map[string]bool{}
+	for _, key := range keys {
+		isKey[strings.ToUpper(key)] = true
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("MERGE INTO ")
+	sb.WriteString(tableName)
+	sb.WriteString(" t USING (SELECT ")
+	for i, column := range columns {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(":")
+		sb.WriteString(strconv.Itoa(i + 1))
+		sb.WriteString(" AS ")
+		sb.WriteString(column)
+	}
+	sb.WriteString(" FROM DUAL) s ON (")
+	for i, key := range keys {
+		if i != 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString("t." + key + " = s." + key)
+	}
+	sb.WriteString(")")
+
+	updates := 0
+	for _, column := range columns {
+		if isKey[strings.ToUpper(column)] {
+			continue
+		}
+		if updates == 0 {
+			sb.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("t." + column + " = s." + column)
+		updates++
+	}
+
+	sb.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	sb.WriteString(strings.Join(columns, ","))
+	sb.WriteString(") VALUES (")
+	for i, column := range columns {
+		if i != 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("s." + column)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package load
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/loption"
+	"testing"
+)
+
+func TestBuildSQL(t *testing.T) {
+	var testCases = []struct {
+		description string
+		columns     []string
+		keys        []string
+		options     []loption.Option
+		expected    string
+		hasError    bool
+	}{
+		{
+			description: "insert",
+			columns:     []string{"ID", "NAME"},
+			expected:    "INSERT INTO FOO(ID,NAME) VALUES (:1,:2)",
+		},
+		{
+			description: "upsert",
+			columns:     []string{"ID", "NAME", "PRICE"},
+			keys:        []string{"ID"},
+			options:     []loption.Option{loption.WithUpsert()},
+			expected:    "MERGE INTO FOO t USING (SELECT :1 AS ID, :2 AS NAME, :3 AS PRICE FROM DUAL) s ON (t.ID = s.ID) WHEN MATCHED THEN UPDATE SET t.NAME = s.NAME, t.PRICE = s.PRICE WHEN NOT MATCHED THEN INSERT (ID,NAME,PRICE) VALUES (s.ID,s.NAME,s.PRICE)",
+		},
+		{
+			description: "upsert with key columns only",
+			columns:     []string{"ID", "CODE"},
+			keys:        []string{"ID", "CODE"},
+			options:     []loption.Option{loption.WithUpsert()},
+			expected:    "MERGE INTO FOO t USING (SELECT :1 AS ID, :2 AS CODE FROM DUAL) s ON (t.ID = s.ID AND t.CODE = s.CODE) WHEN NOT MATCHED THEN INSERT (ID,CODE) VALUES (s.ID,s.CODE)",
+		},
+		{
+			description: "upsert without keys",
+			columns:     []string{"NAME"},
+			options:     []loption.Option{loption.WithUpsert()},
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := BuildSQL("FOO", testCase.columns, testCase.keys, testCase.options...)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expected, actual, testCase.description)
+	}
+}
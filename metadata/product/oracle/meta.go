@@ -4,6 +4,7 @@ import (
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/product/oracle/sequence"
 	"github.com/viant/sqlx/metadata/registry"
 	"log"
 )
@@ -111,6 +112,14 @@ LEFT JOIN (
 			info.NewCriterion(info.Table, "C.TABLE_NAME"),
 		),
 
+		info.NewQuery(info.KindSequenceNextValue, `SELECT 1 FROM DUAL`,
+			oracleProduct,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, ""),
+			info.NewCriterion(info.Object, ""),
+			info.NewCriterion(info.SequenceNewCurrentValue, ""),
+		).OnPre(&sequence.Range{}),
+
 		// Sequences
 		info.NewQuery(info.KindSequences, `SELECT 
 '' AS SEQUENCE_CATALOG,
@@ -191,6 +200,21 @@ WHERE AC.CONSTRAINT_TYPE = 'P'`,
 			info.NewCriterion(info.Table, "ACC.TABLE_NAME"),
 		),
 
+		// Check constraints, NOT NULL constraints are reported as "COLUMN" IS NOT NULL
+		info.NewQuery(info.KindCheckConstraints, `SELECT 
+AC.CONSTRAINT_NAME,
+'' AS CONSTRAINT_CATALOG,
+AC.OWNER AS CONSTRAINT_SCHEMA,
+AC.TABLE_NAME,
+AC.SEARCH_CONDITION_VC AS CHECK_CLAUSE
+FROM ALL_CONSTRAINTS AC
+WHERE AC.CONSTRAINT_TYPE = 'C' AND AC.STATUS = 'ENABLED'`,
+			oracleProduct,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "AC.OWNER"),
+			info.NewCriterion(info.Table, "AC.TABLE_NAME"),
+		).OnPre(info.MinVersion(12, 2, 0)), //SEARCH_CONDITION_VC requires Oracle 12.2+
+
 		// Foreign keys
 		info.NewQuery(info.KindForeignKeys, `SELECT 
 FK.CONSTRAINT_NAME,
@@ -256,6 +280,7 @@ FROM DUAL`, oracleProduct),
 		QuoteCharacter:          '\'',
 		CanAutoincrement:        false,
 		CanLastInsertID:         false,
+		CanRowValueIn:           true,
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
 	})
 }
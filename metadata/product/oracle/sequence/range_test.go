@@ -0,0 +1,11 @@
+package sequence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildRangeSQL(t *testing.T) {
+	assert.EqualValues(t, "SELECT APP.ORDERS_SEQ.NEXTVAL FROM DUAL CONNECT BY LEVEL <= 3", BuildRangeSQL("APP", "ORDERS_SEQ", 3))
+	assert.EqualValues(t, "SELECT ORDERS_SEQ.NEXTVAL FROM DUAL CONNECT BY LEVEL <= 1", BuildRangeSQL("", "ORDERS_SEQ", 1))
+}
@@ -0,0 +1,42 @@
+package pg
+
+import (
+	"errors"
+	"github.com/viant/sqlx/io/errx"
+	"regexp"
+)
+
+// sqlStateError is implemented by lib/pq and jackc/pgx errors
+type sqlStateError interface {
+	SQLState() string
+}
+
+var sqlState = regexp.MustCompile(`SQLSTATE ([0-9A-Z]{5})`)
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	var code string
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		code = stateErr.SQLState()
+	} else if match := sqlState.FindStringSubmatch(err.Error()); len(match) > 0 {
+		code = match[1]
+	}
+	kind := errx.ClassifySQLState(code)
+	if kind == nil {
+		return nil
+	}
+	message := err.Error()
+	result := &errx.Classification{Kind: kind, Code: code}
+	switch kind {
+	case errx.ErrNotNull:
+		result.Column = errx.QuotedName(message, "column")
+		result.Table = errx.QuotedName(message, "relation")
+	default:
+		result.Constraint = errx.QuotedName(message, "constraint")
+	}
+	return result
+}
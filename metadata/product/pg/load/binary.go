@@ -0,0 +1,615 @@
+package load
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/xunsafe"
+	goIo "io"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	binaryHeader  = []byte("PGCOPY\n\377\r\n\000\000\000\000\000\000\000\000\000")
+	binaryTrailer = []byte{0xFF, 0xFF}
+	pgEpoch       = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+const (
+	numericPositive = 0x0000
+	numericNegative = 0x4000
+	numericNaN      = 0xC000
+)
+
+type (
+	//valueEncoder appends binary representation of non nil value
+	valueEncoder func(dst []byte, value interface{}) ([]byte, error)
+
+	//BinaryEncoder encodes records with COPY binary format
+	BinaryEncoder struct {
+		fields   []*xunsafe.Field
+		encoders []valueEncoder
+		buffer   []byte
+	}
+)
+
+// elementOIDs represents array element type OIDs
+var elementOIDs = map[string]uint32{
+	"bool":        16,
+	"bytea":       17,
+	"int8":        20,
+	"int2":        21,
+	"int4":        23,
+	"text":        25,
+	"json":        114,
+	"float4":      700,
+	"float8":      701,
+	"bpchar":      1042,
+	"varchar":     1043,
+	"date":        1082,
+	"time":        1083,
+	"timestamp":   1114,
+	"timestamptz": 1184,
+	"numeric":     1700,
+	"uuid":        2950,
+	"jsonb":       3802,
+}
+
+func scalarEncoder(pgType string) (valueEncoder, bool) {
+	switch pgType {
+	case "bool":
+		return encodeBool, true
+	case "int2":
+		return intEncoder(2), true
+	case "int4":
+		return intEncoder(4), true
+	case "int8":
+		return intEncoder(8), true
+	case "float4":
+		return encodeFloat4, true
+	case "float8":
+		return encodeFloat8, true
+	case "numeric":
+		return encodeNumeric, true
+	case "text", "varchar", "bpchar", "name", "citext":
+		return encodeText, true
+	case "bytea":
+		return encodeBytea, true
+	case "uuid":
+		return encodeUUID, true
+	case "json":
+		return encodeJSON, true
+	case "jsonb":
+		return encodeJSONB, true
+	case "date":
+		return encodeDate, true
+	case "time":
+		return encodeTime, true
+	case "timestamp":
+		return timestampEncoder(false), true
+	case "timestamptz":
+		return timestampEncoder(true), true
+	}
+	return nil, false
+}
+
+// NewBinaryEncoder creates binary encoder for matched struct fields and corresponding postgres column types (pg_type.typname)
+func NewBinaryEncoder(fields []io.Field, pgTypes []string) (*BinaryEncoder, error) {
+	if len(fields) != len(pgTypes) {
+		return nil, fmt.Errorf("fields and types count mismatch: %v != %v", len(fields), len(pgTypes))
+	}
+	result := &BinaryEncoder{
+		fields:   make([]*xunsafe.Field, len(fields)),
+		encoders: make([]valueEncoder, len(fields)),
+	}
+	for i := range fields {
+		encoder, err := typeEncoder(pgTypes[i])
+		if err != nil {
+			return nil, fmt.Errorf("column %v: %w", fields[i].Column.Name(), err)
+		}
+		result.fields[i] = fields[i].Field
+		result.encoders[i] = encoder
+	}
+	return result, nil
+}
+
+func typeEncoder(pgType string) (valueEncoder, error) {
+	if encoder, ok := scalarEncoder(pgType); ok {
+		return encoder, nil
+	}
+	if strings.HasPrefix(pgType, "_") {
+		elementType := pgType[1:]
+		elementEncoder, ok := scalarEncoder(elementType)
+		if !ok {
+			return nil, fmt.Errorf("unsupported binary array type: %v", pgType)
+		}
+		return arrayEncoder(elementOIDs[elementType], elementEncoder), nil
+	}
+	return nil, fmt.Errorf("unsupported binary type: %v", pgType)
+}
+
+// Header returns binary format header
+func (e *BinaryEncoder) Header() []byte {
+	return binaryHeader
+}
+
+// Trailer returns binary format trailer
+func (e *BinaryEncoder) Trailer() []byte {
+	return binaryTrailer
+}
+
+// Encode encodes record as binary tuple, returned slice is valid till next Encode call
+func (e *BinaryEncoder) Encode(record interface{}) ([]byte, error) {
+	ptr := xunsafe.AsPointer(record)
+	if ptr == nil {
+		return nil, fmt.Errorf("record was nil")
+	}
+	dst := appendInt16(e.buffer[:0], int16(len(e.fields)))
+	var err error
+	for i, field := range e.fields {
+		value := reflect.NewAt(field.Type, field.Pointer(ptr)).Elem().Interface()
+		if dst, err = appendValue(dst, value, e.encoders[i]); err != nil {
+			return nil, fmt.Errorf("failed to encode %v: %w", field.Name, err)
+		}
+	}
+	e.buffer = dst
+	return dst, nil
+}
+
+// Reader returns reader streaming encoded records through a pipe, reader has to be closed once consumer is done
+func (e *BinaryEncoder) Reader(stream *io.RecordStream) *goIo.PipeReader {
+	reader, writer := goIo.Pipe()
+	go func() {
+		_ = writer.CloseWithError(e.write(writer, stream))
+	}()
+	return reader
+}
+
+func (e *BinaryEncoder) write(writer goIo.Writer, stream *io.RecordStream) error {
+	if _, err := writer.Write(e.Header()); err != nil {
+		return err
+	}
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		data, err := e.Encode(record)
+		if err != nil {
+			return err
+		}
+		if _, err = writer.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err := writer.Write(e.Trailer())
+	return err
+}
+
+func appendValue(dst []byte, value interface{}, encoder valueEncoder) ([]byte, error) {
+	value, err := normalizeValue(value)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return appendInt32(dst, -1), nil
+	}
+	offset := len(dst)
+	dst = append(dst, 0, 0, 0, 0)
+	if dst, err = encoder(dst, value); err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint32(dst[offset:], uint32(len(dst)-offset-4))
+	return dst, nil
+}
+
+// normalizeValue dereferences pointers and resolves driver.Valuer, it returns nil for NULL (nil pointer or nil slice)
+func normalizeValue(value interface{}) (interface{}, error) {
+	for value != nil {
+		if rValue := reflect.ValueOf(value); rValue.Kind() == reflect.Slice && rValue.IsNil() {
+			return nil, nil
+		}
+		switch actual := value.(type) {
+		case time.Time, json.RawMessage, []byte, string, uuid.UUID:
+			return value, nil
+		case *big.Float, *big.Int, *big.Rat: //numeric encoder uses pointer methods
+			if reflect.ValueOf(value).IsNil() {
+				return nil, nil
+			}
+			return value, nil
+		case big.Float:
+			return &actual, nil
+		case big.Int:
+			return &actual, nil
+		case big.Rat:
+			return &actual, nil
+		case driver.Valuer:
+			rValue := reflect.ValueOf(value)
+			if rValue.Kind() == reflect.Ptr && rValue.IsNil() {
+				return nil, nil
+			}
+			return actual.Value()
+		}
+		rValue := reflect.ValueOf(value)
+		if rValue.Kind() != reflect.Ptr {
+			return value, nil
+		}
+		if rValue.IsNil() {
+			return nil, nil
+		}
+		value = rValue.Elem().Interface()
+	}
+	return nil, nil
+}
+
+func encodeBool(dst []byte, value interface{}) ([]byte, error) {
+	switch actual := value.(type) {
+	case bool:
+		if actual {
+			return append(dst, 1), nil
+		}
+		return append(dst, 0), nil
+	case string:
+		b, err := strconv.ParseBool(actual)
+		if err != nil {
+			return nil, err
+		}
+		return encodeBool(dst, b)
+	}
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() == reflect.Bool {
+		return encodeBool(dst, rValue.Bool())
+	}
+	return nil, fmt.Errorf("unable to encode %T as bool", value)
+}
+
+func intEncoder(size int) valueEncoder {
+	bits := uint(size*8 - 1)
+	max, min := int64(1)<<bits-1, -(int64(1) << bits)
+	return func(dst []byte, value interface{}) ([]byte, error) {
+		v, err := asInt64(value)
+		if err != nil {
+			return nil, err
+		}
+		if size < 8 && (v > max || v < min) {
+			return nil, fmt.Errorf("value %v out of range for %v byte integer", v, size)
+		}
+		switch size {
+		case 2:
+			return appendInt16(dst, int16(v)), nil
+		case 4:
+			return appendInt32(dst, int32(v)), nil
+		}
+		return binary.BigEndian.AppendUint64(dst, uint64(v)), nil
+	}
+}
+
+func asInt64(value interface{}) (int64, error) {
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rValue.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v := rValue.Uint()
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("value %v out of range for integer", v)
+		}
+		return int64(v), nil
+	case reflect.Float32, reflect.Float64:
+		v := rValue.Float()
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("unable to encode fractional value %v as integer", v)
+		}
+		return int64(v), nil
+	case reflect.Bool:
+		if rValue.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(rValue.String()), 10, 64)
+	}
+	return 0, fmt.Errorf("unable to encode %T as integer", value)
+}
+
+func asFloat64(value interface{}) (float64, error) {
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rValue.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rValue.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rValue.Uint()), nil
+	case reflect.String:
+		return strconv.ParseFloat(strings.TrimSpace(rValue.String()), 64)
+	}
+	return 0, fmt.Errorf("unable to encode %T as float", value)
+}
+
+func encodeFloat4(dst []byte, value interface{}) ([]byte, error) {
+	v, err := asFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint32(dst, math.Float32bits(float32(v))), nil
+}
+
+func encodeFloat8(dst []byte, value interface{}) ([]byte, error) {
+	v, err := asFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64(dst, math.Float64bits(v)), nil
+}
+
+func encodeNumeric(dst []byte, value interface{}) ([]byte, error) {
+	var text string
+	rValue := reflect.ValueOf(value)
+	switch actual := value.(type) {
+	case *big.Float:
+		text = actual.Text('f', -1)
+	case *big.Int:
+		text = actual.String()
+	case *big.Rat:
+		text = actual.FloatString(16)
+	case []byte:
+		text = string(actual)
+	default:
+		switch rValue.Kind() {
+		case reflect.Float32, reflect.Float64:
+			v := rValue.Float()
+			if math.IsNaN(v) {
+				text = "NaN"
+			} else if math.IsInf(v, 0) {
+				return nil, fmt.Errorf("unable to encode %v as numeric", v)
+			} else {
+				text = strconv.FormatFloat(v, 'f', -1, rValue.Type().Bits())
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			text = strconv.FormatInt(rValue.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			text = strconv.FormatUint(rValue.Uint(), 10)
+		case reflect.String:
+			text = rValue.String()
+		default:
+			return nil, fmt.Errorf("unable to encode %T as numeric", value)
+		}
+	}
+	return appendNumeric(dst, text)
+}
+
+// appendNumeric appends numeric in base 10000 digits format: ndigits, weight, sign, dscale, digits
+func appendNumeric(dst []byte, text string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+	if strings.EqualFold(text, "NaN") {
+		return append(dst, 0, 0, 0, 0, byte(numericNaN>>8), 0, 0, 0), nil
+	}
+	if strings.ContainsAny(text, "eE") {
+		f, _, err := big.ParseFloat(text, 10, 256, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid numeric: %v", text)
+		}
+		text = f.Text('f', -1)
+	}
+
+	sign := uint16(numericPositive)
+	switch {
+	case strings.HasPrefix(text, "-"):
+		sign = numericNegative
+		text = text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	intPart, fracPart := text, ""
+	if index := strings.IndexByte(text, '.'); index != -1 {
+		intPart, fracPart = text[:index], text[index+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return nil, fmt.Errorf("invalid numeric: %v", text)
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return nil, fmt.Errorf("invalid numeric: %v", text)
+			}
+		}
+	}
+
+	dscale := len(fracPart)
+	intPart = strings.TrimLeft(intPart, "0")
+	if pad := len(intPart) % 4; pad != 0 {
+		intPart = strings.Repeat("0", 4-pad) + intPart
+	}
+	if pad := len(fracPart) % 4; pad != 0 {
+		fracPart += strings.Repeat("0", 4-pad)
+	}
+
+	digitsText := intPart + fracPart
+	digits := make([]int16, 0, len(digitsText)/4)
+	for i := 0; i < len(digitsText); i += 4 {
+		digit, _ := strconv.Atoi(digitsText[i : i+4])
+		digits = append(digits, int16(digit))
+	}
+	weight := len(intPart)/4 - 1
+	for len(digits) > 0 && digits[0] == 0 {
+		digits = digits[1:]
+		weight--
+	}
+	for len(digits) > 0 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) == 0 {
+		weight = 0
+		sign = numericPositive
+	}
+
+	dst = appendInt16(dst, int16(len(digits)))
+	dst = appendInt16(dst, int16(weight))
+	dst = binary.BigEndian.AppendUint16(dst, sign)
+	dst = appendInt16(dst, int16(dscale))
+	for _, digit := range digits {
+		dst = appendInt16(dst, digit)
+	}
+	return dst, nil
+}
+
+func encodeText(dst []byte, value interface{}) ([]byte, error) {
+	switch actual := value.(type) {
+	case string:
+		return append(dst, actual...), nil
+	case []byte:
+		return append(dst, actual...), nil
+	case fmt.Stringer:
+		return append(dst, actual.String()...), nil
+	}
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() == reflect.String {
+		return append(dst, rValue.String()...), nil
+	}
+	return append(dst, fmt.Sprint(value)...), nil
+}
+
+func encodeBytea(dst []byte, value interface{}) ([]byte, error) {
+	switch actual := value.(type) {
+	case []byte:
+		return append(dst, actual...), nil
+	case string:
+		return append(dst, actual...), nil
+	}
+	return nil, fmt.Errorf("unable to encode %T as bytea", value)
+}
+
+func encodeUUID(dst []byte, value interface{}) ([]byte, error) {
+	switch actual := value.(type) {
+	case uuid.UUID:
+		return append(dst, actual[:]...), nil
+	case [16]byte:
+		return append(dst, actual[:]...), nil
+	case []byte:
+		if len(actual) == 16 {
+			return append(dst, actual...), nil
+		}
+		return encodeUUID(dst, string(actual))
+	case string:
+		id, err := uuid.Parse(actual)
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, id[:]...), nil
+	}
+	return nil, fmt.Errorf("unable to encode %T as uuid", value)
+}
+
+func encodeJSON(dst []byte, value interface{}) ([]byte, error) {
+	switch actual := value.(type) {
+	case json.RawMessage:
+		return append(dst, actual...), nil
+	case []byte:
+		return append(dst, actual...), nil
+	case string:
+		return append(dst, actual...), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, data...), nil
+}
+
+func encodeJSONB(dst []byte, value interface{}) ([]byte, error) {
+	return encodeJSON(append(dst, 1), value) //jsonb binary format version
+}
+
+func asTime(value interface{}) (time.Time, error) {
+	switch actual := value.(type) {
+	case time.Time:
+		return actual, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, actual)
+	}
+	return time.Time{}, fmt.Errorf("unable to encode %T as time", value)
+}
+
+func timestampEncoder(withZone bool) valueEncoder {
+	return func(dst []byte, value interface{}) ([]byte, error) {
+		ts, err := asTime(value)
+		if err != nil {
+			return nil, err
+		}
+		if withZone {
+			ts = ts.UTC()
+		} else {
+			ts = time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(), time.UTC)
+		}
+		micros := ts.Unix()*1000000 + int64(ts.Nanosecond()/1000) - pgEpoch.Unix()*1000000
+		return binary.BigEndian.AppendUint64(dst, uint64(micros)), nil
+	}
+}
+
+func encodeDate(dst []byte, value interface{}) ([]byte, error) {
+	ts, err := asTime(value)
+	if err != nil {
+		return nil, err
+	}
+	date := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+	days := int32(date.Sub(pgEpoch).Hours() / 24)
+	return appendInt32(dst, days), nil
+}
+
+func encodeTime(dst []byte, value interface{}) ([]byte, error) {
+	ts, err := asTime(value)
+	if err != nil {
+		return nil, err
+	}
+	micros := int64(ts.Hour())*3600000000 + int64(ts.Minute())*60000000 + int64(ts.Second())*1000000 + int64(ts.Nanosecond()/1000)
+	return binary.BigEndian.AppendUint64(dst, uint64(micros)), nil
+}
+
+// arrayEncoder encodes one dimensional array: ndim, has null flag, element oid, dimension size, lower bound, elements
+func arrayEncoder(elementOID uint32, elementEncoder valueEncoder) valueEncoder {
+	return func(dst []byte, value interface{}) ([]byte, error) {
+		rValue := reflect.ValueOf(value)
+		if rValue.Kind() != reflect.Slice && rValue.Kind() != reflect.Array {
+			return nil, fmt.Errorf("unable to encode %T as array", value)
+		}
+		size := rValue.Len()
+		dst = appendInt32(dst, 1)
+		hasNullOffset := len(dst)
+		dst = appendInt32(dst, 0)
+		dst = binary.BigEndian.AppendUint32(dst, elementOID)
+		dst = appendInt32(dst, int32(size))
+		dst = appendInt32(dst, 1)
+		var err error
+		for i := 0; i < size; i++ {
+			item := rValue.Index(i).Interface()
+			if normalized, _ := normalizeValue(item); normalized == nil {
+				binary.BigEndian.PutUint32(dst[hasNullOffset:], 1)
+			}
+			if dst, err = appendValue(dst, item, elementEncoder); err != nil {
+				return nil, fmt.Errorf("array element %v: %w", i, err)
+			}
+		}
+		return dst, nil
+	}
+}
+
+func appendInt16(dst []byte, v int16) []byte {
+	return binary.BigEndian.AppendUint16(dst, uint16(v))
+}
+
+func appendInt32(dst []byte, v int32) []byte {
+	return binary.BigEndian.AppendUint32(dst, uint32(v))
+}
@@ -0,0 +1,162 @@
+package load
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/loption"
+	goIo "io"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypeEncoder(t *testing.T) {
+	id := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	var nilInt *int
+	var nilInts []int
+	var nilBigInt *big.Int
+	ten := 10
+	testCases := []struct {
+		description string
+		pgType      string
+		value       interface{}
+		expected    []byte
+		hasError    bool
+	}{
+		{description: "bool", pgType: "bool", value: true, expected: []byte{0, 0, 0, 1, 1}},
+		{description: "int2", pgType: "int2", value: -2, expected: []byte{0, 0, 0, 2, 0xFF, 0xFE}},
+		{description: "int4 pointer", pgType: "int4", value: &ten, expected: []byte{0, 0, 0, 4, 0, 0, 0, 10}},
+		{description: "int4 nil", pgType: "int4", value: nilInt, expected: []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+		{description: "int2 overflow", pgType: "int2", value: 40000, hasError: true},
+		{description: "int8", pgType: "int8", value: int64(1), expected: []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{description: "float8", pgType: "float8", value: 1.5, expected: []byte{0, 0, 0, 8, 0x3F, 0xF8, 0, 0, 0, 0, 0, 0}},
+		{description: "text", pgType: "varchar", value: "abc", expected: []byte{0, 0, 0, 3, 'a', 'b', 'c'}},
+		{description: "numeric", pgType: "numeric", value: "12345.678",
+			expected: []byte{0, 0, 0, 14, 0, 3, 0, 1, 0, 0, 0, 3, 0, 1, 0x09, 0x29, 0x1A, 0x7C}},
+		{description: "numeric fraction", pgType: "numeric", value: 0.0012,
+			expected: []byte{0, 0, 0, 10, 0, 1, 0xFF, 0xFF, 0, 0, 0, 4, 0, 12}},
+		{description: "numeric negative", pgType: "numeric", value: "-1.5",
+			expected: []byte{0, 0, 0, 12, 0, 2, 0, 0, 0x40, 0, 0, 1, 0, 1, 0x13, 0x88}},
+		{description: "numeric NaN", pgType: "numeric", value: "NaN", expected: []byte{0, 0, 0, 8, 0, 0, 0, 0, 0xC0, 0, 0, 0}},
+		{description: "numeric invalid", pgType: "numeric", value: "1.2.3", hasError: true},
+		{description: "numeric big.Float", pgType: "numeric", value: big.NewFloat(1.5),
+			expected: []byte{0, 0, 0, 12, 0, 2, 0, 0, 0, 0, 0, 1, 0, 1, 0x13, 0x88}},
+		{description: "numeric big.Int", pgType: "numeric", value: big.NewInt(10), expected: []byte{0, 0, 0, 10, 0, 1, 0, 0, 0, 0, 0, 0, 0, 10}},
+		{description: "numeric big.Int value", pgType: "numeric", value: *big.NewInt(10), expected: []byte{0, 0, 0, 10, 0, 1, 0, 0, 0, 0, 0, 0, 0, 10}},
+		{description: "numeric big.Rat", pgType: "numeric", value: big.NewRat(3, 2),
+			expected: []byte{0, 0, 0, 12, 0, 2, 0, 0, 0, 0, 0, 16, 0, 1, 0x13, 0x88}},
+		{description: "numeric nil big.Int", pgType: "numeric", value: nilBigInt, expected: []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+		{description: "uuid", pgType: "uuid", value: id.String(), expected: append([]byte{0, 0, 0, 16}, id[:]...)},
+		{description: "jsonb", pgType: "jsonb", value: `{"a":1}`, expected: append([]byte{0, 0, 0, 8, 1}, `{"a":1}`...)},
+		{description: "date", pgType: "date", value: time.Date(2000, 1, 2, 10, 0, 0, 0, time.UTC), expected: []byte{0, 0, 0, 4, 0, 0, 0, 1}},
+		{description: "timestamp", pgType: "timestamp", value: time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC),
+			expected: []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0x0F, 0x42, 0x40}},
+		{description: "timestamptz", pgType: "timestamptz", value: time.Date(2000, 1, 1, 1, 0, 1, 0, time.FixedZone("", 3600)),
+			expected: []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0x0F, 0x42, 0x40}},
+		{description: "int4 array with null", pgType: "_int4", value: []*int{&ten, nil},
+			expected: []byte{0, 0, 0, 32,
+				0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 23, 0, 0, 0, 2, 0, 0, 0, 1,
+				0, 0, 0, 4, 0, 0, 0, 10,
+				0xFF, 0xFF, 0xFF, 0xFF}},
+		{description: "int4 nil array", pgType: "_int4", value: nilInts, expected: []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+		{description: "int4 empty array", pgType: "_int4", value: []int{},
+			expected: []byte{0, 0, 0, 20, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{description: "unsupported", pgType: "point", value: "(1,2)", hasError: true},
+	}
+
+	for _, testCase := range testCases {
+		encoder, err := typeEncoder(testCase.pgType)
+		if err == nil {
+			var actual []byte
+			actual, err = appendValue(nil, testCase.value, encoder)
+			if !testCase.hasError {
+				assert.EqualValues(t, testCase.expected, actual, testCase.description)
+			}
+		}
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+	}
+}
+
+func TestBinaryEncoder_Reader(t *testing.T) {
+	type Foo struct {
+		ID   int
+		Name *string
+	}
+	name := "foo"
+	records := []*Foo{{ID: 1, Name: &name}, {ID: 2}}
+	fooType := reflect.TypeOf(Foo{})
+	columns := []io.Column{
+		io.NewColumn("ID", "INT", reflect.TypeOf(0)),
+		io.NewColumn("NAME", "TEXT", reflect.TypeOf("")),
+	}
+	fields, err := io.NewMatcher(nil).Match(fooType, columns)
+	if !assert.Nil(t, err) {
+		return
+	}
+	encoder, err := NewBinaryEncoder(fields, []string{"int4", "text"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	stream, _ := io.StreamOf(records)
+	reader := encoder.Reader(stream)
+	data, err := goIo.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Nil(t, reader.Close())
+
+	expected := bytes.NewBuffer(nil)
+	expected.Write(binaryHeader)
+	_ = binary.Write(expected, binary.BigEndian, []int16{2})
+	expected.Write([]byte{0, 0, 0, 4, 0, 0, 0, 1, 0, 0, 0, 3, 'f', 'o', 'o'})
+	_ = binary.Write(expected, binary.BigEndian, []int16{2})
+	expected.Write([]byte{0, 0, 0, 4, 0, 0, 0, 2, 0xFF, 0xFF, 0xFF, 0xFF})
+	expected.Write(binaryTrailer)
+	assert.EqualValues(t, expected.Bytes(), data)
+	assert.Equal(t, `COPY "foo" ("id", "name") FROM STDIN (FORMAT binary)`, BuildBinaryCopySQL("foo", []string{"id", "name"}))
+}
+
+func TestBuildCopySQL(t *testing.T) {
+	testCases := []struct {
+		description string
+		table       string
+		format      string
+		expected    string
+	}{
+		{description: "default text format", table: "foo", expected: `COPY "foo" ("id", "name") FROM STDIN`},
+		{description: "csv format", table: "foo", format: FormatCSV, expected: `COPY "foo" ("id", "name") FROM STDIN (FORMAT csv)`},
+		{description: "binary format", table: "foo", format: "BINARY", expected: `COPY "foo" ("id", "name") FROM STDIN (FORMAT binary)`},
+		{description: "schema qualified table", table: "public.foo", expected: `COPY "public"."foo" ("id", "name") FROM STDIN`},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, BuildCopySQL(testCase.table, []string{"id", "name"}, testCase.format), testCase.description)
+	}
+}
+
+func TestCheckPgxCopy(t *testing.T) {
+	testCases := []struct {
+		description string
+		options     []loption.Option
+		expectErr   string
+	}{
+		{description: "pgx copy enabled", options: []loption.Option{loption.WithPgxCopy()}},
+		{description: "pgx copy not enabled", expectErr: "binary COPY format requires pgx stdlib driver and loption.WithPgxCopy()"},
+		{description: "pgx copy with transaction", options: []loption.Option{loption.WithPgxCopy(), loption.WithTransaction(&sql.Tx{})},
+			expectErr: "binary COPY format is not supported with transaction, pgx COPY runs on dedicated connection"},
+	}
+	for _, testCase := range testCases {
+		err := checkPgxCopy(loption.NewOptions(testCase.options...), "binary COPY format")
+		if testCase.expectErr == "" {
+			assert.Nil(t, err, testCase.description)
+			continue
+		}
+		assert.EqualError(t, err, testCase.expectErr, testCase.description)
+	}
+}
@@ -0,0 +1,166 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/lib/pq"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/loption"
+	goIo "io"
+	"strings"
+)
+
+const (
+	// FormatBinary represents binary COPY format
+	FormatBinary = "binary"
+	// FormatCSV represents CSV COPY format, applicable to *io.EncodedStream data
+	FormatCSV = "csv"
+)
+
+const columnTypesSQL = `SELECT a.attname,
+CASE WHEN t.typtype = 'e' THEN 'text' WHEN t.typtype = 'd' THEN bt.typname ELSE t.typname END
+FROM pg_attribute a
+JOIN pg_type t ON t.oid = a.atttypid
+LEFT JOIN pg_type bt ON bt.oid = t.typbasetype
+WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped`
+
+// execBinary streams records with binary COPY format through dedicated pgx connection
+func (s *Session) execBinary(ctx context.Context, stream *io.RecordStream, db *sql.DB, tableName string, columns []io.Column, loadOpts *loption.Options) (sql.Result, error) {
+	if err := checkPgxCopy(loadOpts, "binary COPY format"); err != nil {
+		return nil, err
+	}
+
+	matcher := io.NewMatcher(nil)
+	fields, err := matcher.Match(stream.StructType(), columns)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	columnTypes, err := s.columnTypes(ctx, conn, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := s.mapColumnsToLowerCasedNames(columns)
+	pgTypes := make([]string, len(names))
+	for i, name := range names {
+		pgType, ok := columnTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("failed to lookup column %v type in table %v", name, tableName)
+		}
+		pgTypes[i] = pgType
+	}
+
+	encoder, err := NewBinaryEncoder(fields, pgTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := encoder.Reader(stream)
+	defer reader.Close()
+	return copyFrom(ctx, conn, BuildBinaryCopySQL(tableName, names), reader)
+}
+
+// execEncoded streams already encoded data with "COPY FROM STDIN" through dedicated pgx connection, data has to be encoded
+// with text (default), csv or binary COPY format
+func (s *Session) execEncoded(ctx context.Context, stream *io.EncodedStream, db *sql.DB, tableName string, columns []io.Column, loadOpts *loption.Options) (sql.Result, error) {
+	if err := checkPgxCopy(loadOpts, "encoded COPY data"); err != nil {
+		return nil, err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	SQL := BuildCopySQL(tableName, s.mapColumnsToLowerCasedNames(columns), loadOpts.GetFormat())
+	return copyFrom(ctx, conn, SQL, stream.Reader)
+}
+
+// checkPgxCopy checks if pgx COPY was explicitly enabled and no transaction was supplied, since pgx COPY runs outside of it
+func checkPgxCopy(loadOpts *loption.Options, what string) error {
+	if !loadOpts.GetWithPgxCopy() {
+		return fmt.Errorf("%v requires pgx stdlib driver and loption.WithPgxCopy()", what)
+	}
+	if loadOpts.GetTransaction() != nil || loadOpts.GetCommonOptions().Tx() != nil {
+		return fmt.Errorf("%v is not supported with transaction, pgx COPY runs on dedicated connection", what)
+	}
+	return nil
+}
+
+// copyFrom runs "COPY FROM STDIN" statement with supplied reader data, it requires pgx stdlib driver
+func copyFrom(ctx context.Context, conn *sql.Conn, SQL string, reader goIo.Reader) (sql.Result, error) {
+	result := &io.QueryResult{}
+	err := conn.Raw(func(driverConn interface{}) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("COPY FROM STDIN requires pgx stdlib driver, but had %T", driverConn)
+		}
+		counter := &io.CountingReader{Reader: reader}
+		tag, err := stdConn.Conn().PgConn().CopyFrom(ctx, counter, SQL)
+		result.Rows = tag.RowsAffected()
+		result.Bytes = counter.Count
+		return err
+	})
+	return result, err
+}
+
+func (s *Session) columnTypes(ctx context.Context, conn *sql.Conn, tableName string) (map[string]string, error) {
+	rows, err := conn.QueryContext(ctx, columnTypesSQL, quoteTableName(tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %v column types: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	var result = make(map[string]string)
+	for rows.Next() {
+		var name, pgType string
+		if err = rows.Scan(&name, &pgType); err != nil {
+			return nil, err
+		}
+		result[strings.ToLower(name)] = pgType
+	}
+	return result, rows.Err()
+}
+
+// BuildBinaryCopySQL builds "COPY FROM STDIN" binary format statement
+func BuildBinaryCopySQL(tableName string, columns []string) string {
+	return BuildCopySQL(tableName, columns, FormatBinary)
+}
+
+// BuildCopySQL builds "COPY FROM STDIN" statement with quoted table and column names, empty format uses server default text format
+func BuildCopySQL(tableName string, columns []string, format string) string {
+	sb := strings.Builder{}
+	sb.WriteString("COPY ")
+	sb.WriteString(quoteTableName(tableName))
+	sb.WriteString(" (")
+	for i, column := range columns {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(pq.QuoteIdentifier(column))
+	}
+	sb.WriteString(") FROM STDIN")
+	if format != "" {
+		sb.WriteString(" (FORMAT ")
+		sb.WriteString(strings.ToLower(format))
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// quoteTableName quotes table name, schema qualified name parts are quoted separately
+func quoteTableName(tableName string) string {
+	parts := strings.Split(tableName, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
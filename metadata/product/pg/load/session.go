@@ -21,23 +21,37 @@ type Session struct {
 	reader  goIo.Reader
 }
 
-// Exec inserts data to table using "Copy in"
+// Exec inserts data to table using "Copy in" within session transaction, data can be a slice, *io.RecordStream or *io.EncodedStream,
+// with loption.WithPgxCopy and loption.WithFormat(FormatBinary) data is streamed with binary COPY format,
+// with loption.WithPgxCopy *io.EncodedStream data is copied as is with loption.WithFormat COPY format (text by default),
+// both pgx COPY paths require pgx stdlib driver and run on dedicated connection outside of any transaction
 func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...loption.Option) (sql.Result, error) {
-	dataAccessor, size, err := io.Values(data)
+	if encoded, ok := data.(*io.EncodedStream); ok {
+		columns, err := io.StructColumns(encoded.StructType(), io.TagSqlx, option.StructOrderedColumns(true))
+		if err != nil {
+			return nil, err
+		}
+		return s.execEncoded(ctx, encoded, db, tableName, columns, loption.NewOptions(options...))
+	}
+
+	stream, err := io.StreamOf(data)
 	if err != nil {
 		return nil, err
 	}
 
-	actualStructType := io.EnsureDereference(dataAccessor(0))
+	actualStructType := stream.StructType()
 	columns, err := io.StructColumns(actualStructType, io.TagSqlx, option.StructOrderedColumns(true))
 	if err != nil {
 		return nil, err
 	}
 
 	loadOpts := loption.NewOptions(options...)
+	if strings.EqualFold(loadOpts.GetFormat(), FormatBinary) {
+		return s.execBinary(ctx, stream, db, tableName, columns, loadOpts)
+	}
 	var opts []option.Option = loadOpts.GetCommonOptions()
 
-	mapper, err := read.NewSQLStructMapper(columns, actualStructType, columnResolver, opts...)
+	mapper, err := read.NewSQLStructMapper(columns, actualStructType, columnResolver, read.WithOptions(opts...))
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +67,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		return nil, s.end(err)
 	}
 
-	result, err := s.load(ctx, dataAccessor, size, mapper, stmt)
+	result, err := s.load(ctx, stream, mapper, stmt)
 	if err != nil {
 		return result, s.end(err)
 	}
@@ -63,20 +77,23 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 
 }
 
-func (s *Session) load(ctx context.Context, dataAccessor io.ValueAccessor, size int, mapper read.RowMapper, stmt *sql.Stmt) (sql.Result, error) {
-	var ptrs []interface{}
-	var err error
-	for i := 0; i < size; i++ {
-		ptrs, err = mapper(dataAccessor(i))
+func (s *Session) load(ctx context.Context, stream *io.RecordStream, mapper read.RowMapper, stmt *sql.Stmt) (sql.Result, error) {
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			return nil, nil
+		}
 		if err != nil {
 			return nil, err
 		}
-		_, err = stmt.ExecContext(ctx, ptrs...)
+		ptrs, err := mapper(record)
 		if err != nil {
 			return nil, err
 		}
+		if _, err = stmt.ExecContext(ctx, ptrs...); err != nil {
+			return nil, err
+		}
 	}
-	return nil, err
 }
 
 func (s *Session) mapColumnsToLowerCasedNames(columns []io.Column) []string {
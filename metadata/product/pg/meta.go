@@ -226,6 +226,22 @@ WHERE s.CONSTRAINT_TYPE = 'FOREIGN KEY'
 			info.NewCriterion(info.Table, "c.TABLE_NAME"),
 		),
 
+		info.NewQuery(info.KindCheckConstraints, `SELECT 
+con.conname AS CONSTRAINT_NAME,
+current_database() AS CONSTRAINT_CATALOG,
+n.nspname AS CONSTRAINT_SCHEMA,
+t.relname AS TABLE_NAME,
+pg_get_constraintdef(con.oid) AS CHECK_CLAUSE
+FROM pg_constraint con
+JOIN pg_class t ON t.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE con.contype = 'c'
+`, pgSQL9,
+			info.NewCriterion(info.Catalog, "current_database()"),
+			info.NewCriterion(info.Schema, "n.nspname"),
+			info.NewCriterion(info.Table, "t.relname"),
+		),
+
 		info.NewQuery(info.KindSession, `SELECT 
     CAST(pid AS varchar) AS PID,
 	datname AS CATALOG_NAME,
@@ -249,6 +265,11 @@ WHERE pid=pg_backend_pid() LIMIT 1;
 			info.NewCriterion(info.Schema, ""),
 			info.NewCriterion(info.Table, ""),
 		),
+		info.NewQuery(info.KindReplicationLag, `SELECT CASE WHEN pg_is_in_recovery() 
+THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) 
+ELSE 0 END AS LAG_SECONDS`,
+			pgSQL9,
+		),
 	)
 	if err != nil {
 		log.Printf("failed to register queries: %v", err)
@@ -263,11 +284,14 @@ WHERE pid=pg_backend_pid() LIMIT 1;
 		Load:                    dialect.LoadTypeUnsupported,
 		CanAutoincrement:        true,
 		CanLastInsertID:         false,
+		CanRowValueIn:           true,
 		CanReturning:            true,
 		QuoteCharacter:          '\'',
 		PlaceholderResolver:     &PlaceholderGenerator{},
 		AutoincrementFunc:       "nextval",
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Explain:                 "EXPLAIN (FORMAT JSON) ",
+		SkipLocked:              true,
 	})
 
 }
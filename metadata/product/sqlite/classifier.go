@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"github.com/viant/sqlx/io/errx"
+	"strings"
+)
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "UNIQUE constraint failed:"), strings.Contains(message, "PRIMARY KEY constraint failed:"):
+		result := &errx.Classification{Kind: errx.ErrDuplicateKey}
+		result.Table, result.Column = failedColumn(message)
+		return result
+	case strings.Contains(message, "FOREIGN KEY constraint failed"):
+		return &errx.Classification{Kind: errx.ErrForeignKey}
+	case strings.Contains(message, "NOT NULL constraint failed:"):
+		result := &errx.Classification{Kind: errx.ErrNotNull}
+		result.Table, result.Column = failedColumn(message)
+		return result
+	case strings.Contains(message, "CHECK constraint failed:"):
+		return &errx.Classification{Kind: errx.ErrCheck, Constraint: failedDetail(message)}
+	case strings.Contains(message, "database is locked"), strings.Contains(message, "database table is locked"):
+		return &errx.Classification{Kind: errx.ErrLockTimeout}
+	}
+	return nil
+}
+
+// failedColumn returns table and first column from "... constraint failed: table.column[, table.column]"
+func failedColumn(message string) (string, string) {
+	detail := failedDetail(message)
+	if index := strings.Index(detail, ","); index != -1 {
+		detail = detail[:index]
+	}
+	if index := strings.Index(detail, "."); index != -1 {
+		return detail[:index], detail[index+1:]
+	}
+	return "", detail
+}
+
+func failedDetail(message string) string {
+	detail := message[strings.LastIndex(message, "failed:")+len("failed:"):]
+	if index := strings.Index(detail, " ("); index != -1 { //modernc.org/sqlite appends extended code
+		detail = detail[:index]
+	}
+	return strings.TrimSpace(detail)
+}
@@ -0,0 +1,10 @@
+package load
+
+import (
+	"github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/metadata/registry"
+)
+
+func init() {
+	registry.RegisterLoad(NewSession, sqlite.SQLite3().Name)
+}
@@ -0,0 +1,46 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Pragma represents SQLite pragma applied for the load duration
+type Pragma struct {
+	Name  string
+	Value string
+}
+
+// defaultPragmas trade durability for speed, previous values are restored once load completes
+var defaultPragmas = []Pragma{
+	{Name: "synchronous", Value: "OFF"},
+	{Name: "temp_store", Value: "MEMORY"},
+	{Name: "cache_size", Value: "-65536"},
+}
+
+// applyPragmas sets pragmas on connection, it returns pragmas restoring previous values
+func applyPragmas(ctx context.Context, conn *sql.Conn, pragmas []Pragma) ([]Pragma, error) {
+	var previous = make([]Pragma, 0, len(pragmas))
+	for _, pragma := range pragmas {
+		var value string
+		if err := conn.QueryRowContext(ctx, "PRAGMA "+pragma.Name).Scan(&value); err != nil {
+			return previous, fmt.Errorf("failed to read pragma %v: %w", pragma.Name, err)
+		}
+		if _, err := conn.ExecContext(ctx, "PRAGMA "+pragma.Name+" = "+pragma.Value); err != nil {
+			return previous, fmt.Errorf("failed to set pragma %v: %w", pragma.Name, err)
+		}
+		previous = append(previous, Pragma{Name: pragma.Name, Value: value})
+	}
+	return previous, nil
+}
+
+func restorePragmas(ctx context.Context, conn *sql.Conn, pragmas []Pragma) error {
+	var err error
+	for i := len(pragmas) - 1; i >= 0; i-- {
+		if _, pErr := conn.ExecContext(ctx, "PRAGMA "+pragmas[i].Name+" = "+pragmas[i].Value); pErr != nil && err == nil {
+			err = fmt.Errorf("failed to restore pragma %v: %w", pragmas[i].Name, pErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/loption"
+	"github.com/viant/sqlx/metadata/info"
+	goIo "io"
+	"sort"
+)
+
+const (
+	// maxVariables represents SQLITE_MAX_VARIABLE_NUMBER default for SQLite prior 3.32
+	maxVariables = 999
+	// defaultBatchSize represents default number of rows inserted with one statement
+	defaultBatchSize = 500
+)
+
+// BulkOptions represents SQLite load options passed with loption.WithHint as JSON
+type BulkOptions struct {
+	BatchSize int
+	// Pragmas replaces default bulk pragmas, previous values are restored once load completes
+	Pragmas map[string]string
+}
+
+// Session represents SQLite load session
+type Session struct {
+	*io.Transaction
+	dialect *info.Dialect
+}
+
+// NewSession returns new SQLite load session
+func NewSession(dialect *info.Dialect) io.LoadExecutor {
+	return &Session{
+		dialect: dialect,
+	}
+}
+
+// Exec inserts data to table using prepared multi rows "INSERT" statements within one transaction,
+// unless transaction is supplied with loption.WithTransaction, connection pragmas are tuned for bulk load
+func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...loption.Option) (sql.Result, error) {
+	if _, ok := data.(*io.EncodedStream); ok {
+		return nil, fmt.Errorf("unsupported load data: %T, sqlite load requires records", data)
+	}
+
+	loadOpts := loption.NewOptions(options...)
+	bulkOptions, err := s.bulkOptions(loadOpts.GetHint())
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := io.StreamOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, binder, err := io.StructColumnMapper(stream.StructType(), loadOpts.GetCommonOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("failed to load %v: no columns were mapped for %v", tableName, stream.StructType())
+	}
+
+	batchSize := bulkOptions.BatchSize
+	if batchSize*len(columns) > maxVariables {
+		batchSize = maxVariables / len(columns)
+	}
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	if tx := loadOpts.GetTransaction(); tx != nil {
+		s.Transaction = &io.Transaction{Tx: tx, Global: true}
+		return s.load(ctx, stream, binder, io.Columns(columns).Names(), tableName, batchSize, options)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	previous, err := applyPragmas(ctx, conn, bulkOptions.pragmas())
+	defer func() {
+		// previous values are restored with fresh context, connection that failed to restore is discarded from the pool
+		if rErr := restorePragmas(context.Background(), conn, previous); rErr != nil {
+			_ = conn.Raw(func(driverConn interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.Transaction = &io.Transaction{Tx: tx}
+	result, err := s.load(ctx, stream, binder, io.Columns(columns).Names(), tableName, batchSize, options)
+	if err != nil {
+		return nil, s.Transaction.RollbackWithErr(err)
+	}
+	return result, s.Transaction.Commit()
+}
+
+func (s *Session) load(ctx context.Context, stream *io.RecordStream, binder io.PlaceholderBinder, columns []string, tableName string, batchSize int, options []loption.Option) (sql.Result, error) {
+	stmt, err := s.Transaction.PrepareContext(ctx, BuildSQL(tableName, columns, batchSize, options...))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	result := &io.QueryResult{}
+	params := make([]interface{}, batchSize*len(columns))
+	rows := 0
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		binder(record, params[rows*len(columns):], 0, len(columns))
+		if rows++; rows < batchSize {
+			continue
+		}
+		if err = s.exec(ctx, stmt, params, result); err != nil {
+			return nil, err
+		}
+		rows = 0
+	}
+
+	if rows == 0 {
+		return result, nil
+	}
+	tail, err := s.Transaction.PrepareContext(ctx, BuildSQL(tableName, columns, rows, options...))
+	if err != nil {
+		return nil, err
+	}
+	defer tail.Close()
+	return result, s.exec(ctx, tail, params[:rows*len(columns)], result)
+}
+
+func (s *Session) exec(ctx context.Context, stmt *sql.Stmt, params []interface{}, result *io.QueryResult) error {
+	exec, err := stmt.ExecContext(ctx, params...)
+	if err != nil {
+		return err
+	}
+	affected, err := exec.RowsAffected()
+	result.Rows += affected
+	return err
+}
+
+func (s *Session) bulkOptions(hint string) (*BulkOptions, error) {
+	result := &BulkOptions{}
+	if hint != "" {
+		if err := json.Unmarshal([]byte(hint), result); err != nil {
+			return nil, fmt.Errorf("invalid sqlite load hint: %w", err)
+		}
+	}
+	if result.BatchSize <= 0 {
+		result.BatchSize = defaultBatchSize
+	}
+	return result, nil
+}
+
+func (o *BulkOptions) pragmas() []Pragma {
+	if o.Pragmas == nil {
+		return defaultPragmas
+	}
+	var result = make([]Pragma, 0, len(o.Pragmas))
+	for name, value := range o.Pragmas {
+		result = append(result, Pragma{Name: name, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
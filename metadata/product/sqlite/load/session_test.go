@@ -0,0 +1,140 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/loption"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/product/sqlite"
+	"path"
+	"testing"
+)
+
+type Foo struct {
+	ID   int    `sqlx:"name=ID,primaryKey=true"`
+	Name string `sqlx:"name=NAME"`
+}
+
+func TestSession_Exec(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "load.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+
+	var testCases = []struct {
+		description string
+		initSQL     []string
+		data        func() interface{}
+		options     []loption.Option
+		expected    []*Foo
+		affected    int64
+	}{
+		{
+			description: "multi rows insert",
+			data: func() interface{} {
+				return []*Foo{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}}
+			},
+			expected: []*Foo{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}},
+			affected: 3,
+		},
+		{
+			description: "batches with tail",
+			data: func() interface{} {
+				return []Foo{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}, {ID: 4, Name: "D"}, {ID: 5, Name: "E"}}
+			},
+			options:  []loption.Option{loption.WithHint(`{"BatchSize":2}`)},
+			expected: []*Foo{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}, {ID: 4, Name: "D"}, {ID: 5, Name: "E"}},
+			affected: 5,
+		},
+		{
+			description: "upsert",
+			initSQL:     []string{"INSERT INTO FOO(ID, NAME) VALUES(1, 'old'), (2, 'B')"},
+			data: func() interface{} {
+				return []*Foo{{ID: 1, Name: "A"}, {ID: 3, Name: "C"}}
+			},
+			options:  []loption.Option{loption.WithUpsert()},
+			expected: []*Foo{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}},
+			affected: 2,
+		},
+		{
+			description: "record stream",
+			data: func() interface{} {
+				records := make(chan *Foo, 2)
+				records <- &Foo{ID: 1, Name: "A"}
+				records <- &Foo{ID: 2, Name: "B"}
+				close(records)
+				stream, _ := io.NewChanRecordStream(records)
+				return stream
+			},
+			options:  []loption.Option{loption.WithHint(`{"Pragmas":{"journal_mode":"MEMORY"}}`)},
+			expected: []*Foo{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}},
+			affected: 2,
+		},
+	}
+
+	session := NewSession(&info.Dialect{Product: *sqlite.SQLite3(), Transactional: true})
+	for _, testCase := range testCases {
+		for _, SQL := range append([]string{"DROP TABLE IF EXISTS FOO", "CREATE TABLE FOO(ID INTEGER PRIMARY KEY, NAME TEXT)"}, testCase.initSQL...) {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testCase.description)
+		}
+
+		var synchronous int
+		assert.Nil(t, db.QueryRow("PRAGMA synchronous").Scan(&synchronous), testCase.description)
+
+		result, err := session.Exec(context.Background(), testCase.data(), db, "FOO", testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, _ := result.RowsAffected()
+		assert.EqualValues(t, testCase.affected, affected, testCase.description)
+		assert.EqualValues(t, testCase.expected, fetchFoos(t, db), testCase.description)
+
+		var restored int
+		assert.Nil(t, db.QueryRow("PRAGMA synchronous").Scan(&restored), testCase.description)
+		assert.EqualValues(t, synchronous, restored, testCase.description)
+	}
+}
+
+func TestSession_Exec_Transaction(t *testing.T) {
+	db, err := sql.Open("sqlite3", path.Join(t.TempDir(), "load.db"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE FOO(ID INTEGER PRIMARY KEY, NAME TEXT)")
+	assert.Nil(t, err)
+
+	session := NewSession(&info.Dialect{Product: *sqlite.SQLite3(), Transactional: true})
+	tx, err := db.Begin()
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = session.Exec(context.Background(), []*Foo{{ID: 1, Name: "A"}}, db, "FOO", loption.WithTransaction(tx))
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Rollback())
+	assert.Len(t, fetchFoos(t, db), 0)
+
+	_, err = session.Exec(context.Background(), []*Foo{{ID: 1, Name: "A"}, {ID: 1, Name: "B"}}, db, "FOO")
+	assert.NotNil(t, err)
+	assert.Len(t, fetchFoos(t, db), 0)
+}
+
+func fetchFoos(t *testing.T, db *sql.DB) []*Foo {
+	rows, err := db.Query("SELECT ID, NAME FROM FOO ORDER BY ID")
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	defer rows.Close()
+	var result []*Foo
+	for rows.Next() {
+		foo := &Foo{}
+		assert.Nil(t, rows.Scan(&foo.ID, &foo.Name))
+		result = append(result, foo)
+	}
+	return result
+}
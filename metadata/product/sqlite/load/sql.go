@@ -0,0 +1,31 @@
+package load
+
+import (
+	"github.com/viant/sqlx/loption"
+	"strings"
+)
+
+// BuildSQL builds multi rows "INSERT" statement, with loption.WithUpsert it uses "INSERT OR REPLACE"
+func BuildSQL(tableName string, columns []string, rows int, options ...loption.Option) string {
+	opts := loption.NewOptions(options...)
+
+	sb := strings.Builder{}
+	if opts.GetWithUpsert() {
+		sb.WriteString("INSERT OR REPLACE INTO ")
+	} else {
+		sb.WriteString("INSERT INTO ")
+	}
+	sb.WriteString(tableName)
+	sb.WriteString("(")
+	sb.WriteString(strings.Join(columns, ","))
+	sb.WriteString(") VALUES ")
+
+	values := "(" + strings.Repeat("?,", len(columns)-1) + "?)"
+	for i := 0; i < rows; i++ {
+		if i != 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(values)
+	}
+	return sb.String()
+}
@@ -0,0 +1,25 @@
+package load
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/loption"
+	"testing"
+)
+
+func TestBuildSQL(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rows        int
+		options     []loption.Option
+		expected    string
+	}{
+		{description: "single row", rows: 1, expected: "INSERT INTO FOO(ID,NAME) VALUES (?,?)"},
+		{description: "multi rows", rows: 3, expected: "INSERT INTO FOO(ID,NAME) VALUES (?,?),(?,?),(?,?)"},
+		{description: "upsert", rows: 2, options: []loption.Option{loption.WithUpsert()}, expected: "INSERT OR REPLACE INTO FOO(ID,NAME) VALUES (?,?),(?,?)"},
+	}
+
+	for _, testCase := range testCases {
+		actual := BuildSQL("FOO", []string{"ID", "NAME"}, testCase.rows, testCase.options...)
+		assert.EqualValues(t, testCase.expected, actual, testCase.description)
+	}
+}
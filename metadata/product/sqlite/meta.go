@@ -221,6 +221,8 @@ FROM pragma_database_list
 		Load:                    dialect.LoadTypeUnsupported,
 		CanAutoincrement:        true,
 		CanLastInsertID:         true,
+		CanRowValueIn:           true,
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Explain:                 "EXPLAIN QUERY PLAN ",
 	})
 }
@@ -0,0 +1,57 @@
+package sqlserver
+
+import (
+	"errors"
+	"github.com/viant/sqlx/io/errx"
+	"strconv"
+	"strings"
+)
+
+// sqlServerError is implemented by go-mssqldb errors
+type sqlServerError interface {
+	SQLErrorNumber() int32
+}
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	var serverErr sqlServerError
+	if !errors.As(err, &serverErr) {
+		return nil
+	}
+	code := strconv.Itoa(int(serverErr.SQLErrorNumber()))
+	message := err.Error()
+	result := &errx.Classification{Code: code}
+	switch code {
+	case "2627", "2601":
+		result.Kind = errx.ErrDuplicateKey
+		result.Constraint = errx.QuotedName(message, "constraint")
+		if result.Constraint == "" {
+			result.Constraint = errx.QuotedName(message, "index")
+		}
+	case "547":
+		result.Kind = errx.ErrCheck
+		if strings.Contains(message, "FOREIGN KEY") || strings.Contains(message, "REFERENCE") {
+			result.Kind = errx.ErrForeignKey
+		}
+		result.Constraint = errx.QuotedName(message, "constraint")
+		result.Column = errx.QuotedName(message, "column")
+	case "515":
+		result.Kind = errx.ErrNotNull
+		result.Column = errx.QuotedName(message, "column")
+		result.Table = errx.QuotedName(message, "table")
+	case "1205":
+		result.Kind = errx.ErrDeadlock
+	case "1222":
+		result.Kind = errx.ErrLockTimeout
+	case "3960":
+		result.Kind = errx.ErrSerialization
+	case "233", "10053", "10054":
+		result.Kind = errx.ErrConnectionLost
+	default:
+		return nil
+	}
+	return result
+}
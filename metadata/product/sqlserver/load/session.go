@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	mssql "github.com/denisenkom/go-mssqldb"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/loption"
@@ -12,6 +13,7 @@ import (
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 	"github.com/viant/xunsafe"
+	goIo "io"
 )
 
 // Session represents session
@@ -40,12 +42,16 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		}
 	}
 
-	valueAt, dataSize, err := io.Values(data)
+	if _, ok := data.(*io.EncodedStream); ok {
+		return nil, fmt.Errorf("unsupported load data: %T, sqlserver bulk copy can not load encoded data, use records or *io.RecordStream", data)
+	}
+
+	stream, err := io.StreamOf(data)
 	if err != nil {
 		return nil, err
 	}
 
-	dataType := io.EnsureDereference(valueAt(0))
+	dataType := stream.StructType()
 
 	tableColumns, err := s.getMetaColumns(db, tableName)
 	if err != nil {
@@ -77,12 +83,18 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	xStruct := &xunsafe.Struct{Fields: io.Fields(matched).XFields()}
 
 	// TODO Possibly add batching mechanism
-	for i := 0; i < dataSize; i++ {
-		args := asArgs(xStruct, valueAt(i))
-		_, err := stmt.Exec(args...)
+	for {
+		record, err := stream.Next()
+		if err == goIo.EOF {
+			break
+		}
 		if err != nil {
 			return nil, err
 		}
+		args := asArgs(xStruct, record)
+		if _, err = stmt.Exec(args...); err != nil {
+			return nil, err
+		}
 	}
 
 	res, err := stmt.Exec()
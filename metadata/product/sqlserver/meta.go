@@ -4,6 +4,7 @@ import (
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/product/sqlserver/sequence"
 	"github.com/viant/sqlx/metadata/registry"
 	"log"
 )
@@ -236,6 +237,21 @@ JOIN $Args[0].INFORMATION_SCHEMA.KEY_COLUMN_USAGE UQC ON UQC.CONSTRAINT_SCHEMA =
 			info.NewCriterion(info.Table, "FK_TAB.NAME"),
 		),
 
+		info.NewQuery(info.KindCheckConstraints, `SELECT 
+CON.NAME AS CONSTRAINT_NAME,
+DB_NAME() AS CONSTRAINT_CATALOG,
+SCHEMA_NAME(T.SCHEMA_ID) AS CONSTRAINT_SCHEMA,
+T.NAME AS TABLE_NAME,
+CON.DEFINITION AS CHECK_CLAUSE
+FROM $Args[0].SYS.CHECK_CONSTRAINTS CON
+JOIN $Args[0].SYS.OBJECTS T ON CON.PARENT_OBJECT_ID = T.OBJECT_ID
+WHERE CON.IS_DISABLED = 0`,
+			sqlServer,
+			info.NewCriterion(info.Catalog, "DB_NAME()"),
+			info.NewCriterion(info.Schema, "SCHEMA_NAME(T.SCHEMA_ID)"),
+			info.NewCriterion(info.Table, "T.NAME"),
+		),
+
 		info.NewQuery(info.KindConstraints, `WITH CCU2 AS ( 
     SELECT SCHEMA_NAME(T.SCHEMA_ID) AS TABLE_SCHEMA,
     T.NAME AS TABLE_NAME,
@@ -407,6 +423,14 @@ GROUP BY T2.TABLE_CATALOG, T2.TABLE_NAME, I.TYPE, T2.TABLE_SCHEMA, T1.SCHEMA_ID,
 			info.NewCriterion(info.Index, "I.NAME"),
 		),
 
+		info.NewQuery(info.KindSequenceNextValue, `SELECT 1`,
+			sqlServer,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, ""),
+			info.NewCriterion(info.Object, ""),
+			info.NewCriterion(info.SequenceNewCurrentValue, ""),
+		).OnPre(&sequence.Range{}),
+
 		info.NewQuery(info.KindSequences, `SELECT
 T.SEQUENCE_CATALOG,
 T.SEQUENCE_SCHEMA,
@@ -0,0 +1,73 @@
+package sequence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	seqrange "github.com/viant/sqlx/metadata/info/sequence"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/sqlx/option"
+)
+
+// Range represents struct used to reserve identities range
+// using sp_sequence_get_range stored procedure
+type Range struct{}
+
+// Handle reserves sequence values range for record count with sp_sequence_get_range
+//
+// IDENTITY columns can not be preset (explicit values require IDENTITY_INSERT),
+// use sequence backed column default (DEFAULT NEXT VALUE FOR <sequence>) instead
+func (n *Range) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+	options := option.AsOptions(iopts)
+	recordCount := options.RecordCount()
+	if recordCount == 0 {
+		return false, fmt.Errorf("invalid recordCount option, expected > 0, but had: %d", recordCount)
+	}
+	argsOps := options.Args()
+	if argsOps == nil {
+		return false, fmt.Errorf("argsOps was empty")
+	}
+	arguments, err := argsOps.StringN(3)
+	if err != nil {
+		return false, err
+	}
+	seq, ok := target.(*sink.Sequence)
+	if !ok {
+		return false, fmt.Errorf("invalid target, expected :%T, but had: %T", seq, target)
+	}
+	seq.Catalog, seq.Schema, seq.Name = arguments[0], arguments[1], arguments[2]
+
+	var first, incrementBy sql.NullInt64
+	SQL := BuildRangeSQL(seq.Schema, seq.Name, recordCount)
+	if err = db.QueryRowContext(ctx, SQL).Scan(&first, &incrementBy); err != nil {
+		return false, fmt.Errorf("failed to reserve %v values from sequence %v: %w", recordCount, seq.Name, err)
+	}
+	if !first.Valid {
+		return false, fmt.Errorf("failed to reserve values from %v: sequence does not exist, IDENTITY columns can not be preset", seq.Name)
+	}
+	if incrementBy.Int64 <= 0 {
+		return false, fmt.Errorf("unsupported sequence %v increment: %v", seq.Name, incrementBy.Int64)
+	}
+	seq.IncrementBy = incrementBy.Int64
+	seq.SetRange(first.Int64, int64(recordCount))
+	return false, nil
+}
+
+// CanUse returns true if Handle function can be executed
+func (n *Range) CanUse(iopts ...interface{}) bool {
+	options := option.AsOptions(iopts)
+	return options.PresetIDStrategy() == dialect.PresetIDWithSequenceRange
+}
+
+// BuildRangeSQL builds sp_sequence_get_range batch returning first reserved value and sequence increment
+func BuildRangeSQL(schema, sequence string, recordCount int64) string {
+	name := sequence
+	if schema != "" {
+		name = schema + "." + name
+	}
+	return `DECLARE @first SQL_VARIANT, @increment SQL_VARIANT;
+IF OBJECT_ID(N'` + seqrange.Escape(name) + `', 'SO') IS NOT NULL
+EXEC SP_SEQUENCE_GET_RANGE @sequence_name = N'` + seqrange.Escape(name) + `', @range_size = ` + fmt.Sprint(recordCount) + `, @range_first_value = @first OUTPUT, @sequence_increment = @increment OUTPUT;
+SELECT CAST(@first AS BIGINT) AS FIRST_VALUE, CAST(@increment AS BIGINT) AS INCREMENT_BY`
+}
@@ -0,0 +1,39 @@
+package sequence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildRangeSQL(t *testing.T) {
+	var testCases = []struct {
+		description string
+		schema      string
+		sequence    string
+		recordCount int64
+		expect      string
+	}{
+		{
+			description: "schema qualified sequence",
+			schema:      "dbo",
+			sequence:    "ORDERS_SEQ",
+			recordCount: 3,
+			expect: `DECLARE @first SQL_VARIANT, @increment SQL_VARIANT;
+IF OBJECT_ID(N'dbo.ORDERS_SEQ', 'SO') IS NOT NULL
+EXEC SP_SEQUENCE_GET_RANGE @sequence_name = N'dbo.ORDERS_SEQ', @range_size = 3, @range_first_value = @first OUTPUT, @sequence_increment = @increment OUTPUT;
+SELECT CAST(@first AS BIGINT) AS FIRST_VALUE, CAST(@increment AS BIGINT) AS INCREMENT_BY`,
+		},
+		{
+			description: "escaped sequence name",
+			sequence:    "O'SEQ",
+			recordCount: 1,
+			expect: `DECLARE @first SQL_VARIANT, @increment SQL_VARIANT;
+IF OBJECT_ID(N'O''SEQ', 'SO') IS NOT NULL
+EXEC SP_SEQUENCE_GET_RANGE @sequence_name = N'O''SEQ', @range_size = 1, @range_first_value = @first OUTPUT, @sequence_increment = @increment OUTPUT;
+SELECT CAST(@first AS BIGINT) AS FIRST_VALUE, CAST(@increment AS BIGINT) AS INCREMENT_BY`,
+		},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, BuildRangeSQL(testCase.schema, testCase.sequence, testCase.recordCount), testCase.description)
+	}
+}
@@ -0,0 +1,39 @@
+package vertica
+
+import (
+	"github.com/viant/sqlx/io/errx"
+	"regexp"
+	"strings"
+)
+
+var sqlState = regexp.MustCompile(`\[([0-9A-Z]{5})\]`)
+
+func init() {
+	errx.RegisterClassifier(product, classify)
+}
+
+func classify(err error) *errx.Classification {
+	message := err.Error()
+	match := sqlState.FindStringSubmatch(message)
+	if len(match) == 0 {
+		return nil
+	}
+	result := &errx.Classification{Code: match[1]}
+	switch match[1] {
+	case "40V01":
+		result.Kind = errx.ErrDeadlock
+	case "55V03":
+		result.Kind = errx.ErrLockTimeout
+	default:
+		if result.Kind = errx.ClassifySQLState(match[1]); result.Kind == nil {
+			return nil
+		}
+	}
+	if constraint := errx.QuotedName(message, "constraint"); constraint != "" {
+		result.Constraint = constraint[strings.LastIndex(constraint, ".")+1:]
+	}
+	if result.Kind == errx.ErrNotNull {
+		result.Column = errx.QuotedName(message, "column")
+	}
+	return result
+}
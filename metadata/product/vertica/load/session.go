@@ -9,6 +9,8 @@ import (
 	"github.com/viant/sqlx/loption"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
+	goIo "io"
+	"reflect"
 )
 
 // To specify the ObjectSeparator (RECORD TERMINATOR) as non-printing characters,
@@ -37,8 +39,9 @@ func NewSession(dialect *info.Dialect) io.LoadExecutor {
 }
 
 // Exec inserts given data to database using "COPY FROM STDIN "
+// data can be a slice, *io.RecordStream or *io.EncodedStream with data encoded with Vertica load config
 func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...loption.Option) (sql.Result, error) {
-	dataReader, dataType, err := csv.NewReader(data, verticaLoadConfig)
+	dataReader, csvReader, dataType, err := newReader(data)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +51,9 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		return nil, err
 	}
 
+	counter := &io.CountingReader{Reader: dataReader}
 	vCtx := vcontext.NewVerticaContext(ctx)
-	err = vCtx.SetCopyInputStream(dataReader)
+	err = vCtx.SetCopyInputStream(counter)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +71,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		result.Result, err = s.Transaction.ExecContext(vCtx, SQL)
 		err = s.end(err)
 	}
+	result.Bytes = counter.Count
 
 	if err != nil {
 		return result, err
@@ -74,14 +79,29 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 
 	// Omitting bug: 0 affected rows
 	result.Rows, err = result.Result.RowsAffected()
-	if err != nil && err.Error() == "no RowsAffected available after DDL statement" {
-		result.Rows = int64(dataReader.ItemCount()) //assigning explicitly value
+	if err != nil && err.Error() == "no RowsAffected available after DDL statement" && csvReader != nil {
+		result.Rows = int64(csvReader.ItemCount()) //assigning explicitly value
 		return result, nil
 	}
 
 	return result, err
 }
 
+func newReader(data interface{}) (goIo.Reader, *csv.Reader, reflect.Type, error) {
+	switch actual := data.(type) {
+	case *io.EncodedStream:
+		return actual.Reader, nil, actual.StructType(), nil
+	case *io.RecordStream:
+		reader, dataType, err := csv.NewStreamReader(actual, verticaLoadConfig)
+		return reader, reader, dataType, err
+	}
+	reader, dataType, err := csv.NewReader(data, verticaLoadConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return reader, reader, dataType, nil
+}
+
 func (s *Session) begin(ctx context.Context, db *sql.DB, options []loption.Option) error {
 	var err error
 	loadOpts := loption.NewOptions(options...)
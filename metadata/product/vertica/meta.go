@@ -4,6 +4,7 @@ import (
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/product/vertica/sequence"
 	"github.com/viant/sqlx/metadata/registry"
 	"log"
 )
@@ -268,6 +269,14 @@ WHERE 1=0`,
 			info.NewCriterion(info.Index, "INDEX_NAME"),
 		),
 
+		info.NewQuery(info.KindSequenceNextValue, `SELECT 1`,
+			vertica,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, ""),
+			info.NewCriterion(info.Object, ""),
+			info.NewCriterion(info.SequenceNewCurrentValue, ""),
+		).OnPre(&sequence.Range{}),
+
 		info.NewQuery(info.KindSequences, `SELECT
 '' SEQUENCE_CATALOG,
 S.SEQUENCE_SCHEMA,
@@ -331,7 +340,9 @@ FROM V_MONITOR.SESSIONS`,
 		QuoteCharacter:          '\'',
 		CanAutoincrement:        true,
 		CanLastInsertID:         true, // LAST_INSERT_ID works only with AUTO_INCREMENT and IDENTITY columns
+		CanRowValueIn:           true,
 		AutoincrementFunc:       "nextval",
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Explain:                 "EXPLAIN ",
 	})
 }
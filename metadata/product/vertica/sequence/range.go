@@ -0,0 +1,77 @@
+package sequence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	seqrange "github.com/viant/sqlx/metadata/info/sequence"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/sqlx/option"
+)
+
+// Range represents struct used to reserve identities range
+// using sequence NEXTVAL over TIMESERIES generated rows
+type Range struct{}
+
+// Handle reserves sequence values range for record count, fetched values are used only if they form continuous range
+// (session cache boundary can split them), otherwise it retries
+func (n *Range) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+	options := option.AsOptions(iopts)
+	recordCount := options.RecordCount()
+	if recordCount == 0 {
+		return false, fmt.Errorf("invalid recordCount option, expected > 0, but had: %d", recordCount)
+	}
+	argsOps := options.Args()
+	if argsOps == nil {
+		return false, fmt.Errorf("argsOps was empty")
+	}
+	arguments, err := argsOps.StringN(3)
+	if err != nil {
+		return false, err
+	}
+	seq, ok := target.(*sink.Sequence)
+	if !ok {
+		return false, fmt.Errorf("invalid target, expected :%T, but had: %T", seq, target)
+	}
+	seq.Catalog, seq.Schema, seq.Name = arguments[0], arguments[1], arguments[2]
+	if err = db.QueryRowContext(ctx, buildIncrementSQL(seq.Schema, seq.Name)).Scan(&seq.IncrementBy); err != nil {
+		return false, fmt.Errorf("failed to lookup sequence %v: %w", seq.Name, err)
+	}
+	if seq.IncrementBy <= 0 {
+		return false, fmt.Errorf("unsupported sequence %v increment: %v", seq.Name, seq.IncrementBy)
+	}
+	first, err := seqrange.ReserveRange(ctx, db, BuildRangeSQL(seq.Schema, seq.Name, recordCount), seq.Name, recordCount, seq.IncrementBy)
+	if err != nil {
+		return false, err
+	}
+	seq.SetRange(first, recordCount)
+	return false, nil
+}
+
+// CanUse returns true if Handle function can be executed
+func (n *Range) CanUse(iopts ...interface{}) bool {
+	options := option.AsOptions(iopts)
+	return options.PresetIDStrategy() == dialect.PresetIDWithSequenceRange
+}
+
+// BuildRangeSQL builds query returning record count sequence next values
+func BuildRangeSQL(schema, sequence string, recordCount int64) string {
+	name := sequence
+	if schema != "" {
+		name = schema + "." + name
+	}
+	return fmt.Sprintf(`SELECT NEXTVAL('%v') FROM (
+SELECT SLICE_TIME FROM (
+SELECT '2000-01-01 00:00:00'::TIMESTAMP AS TM UNION ALL
+SELECT '2000-01-01 00:00:00'::TIMESTAMP + INTERVAL '%v SECOND'
+) T TIMESERIES SLICE_TIME AS '1 SECOND' OVER (ORDER BY TM)) S`, seqrange.Escape(name), recordCount-1)
+}
+
+func buildIncrementSQL(schema, sequence string) string {
+	SQL := "SELECT INCREMENT_BY FROM V_CATALOG.SEQUENCES WHERE SEQUENCE_NAME = '" + seqrange.Escape(sequence) + "'"
+	if schema != "" {
+		SQL += " AND SEQUENCE_SCHEMA = '" + seqrange.Escape(schema) + "'"
+	}
+	return SQL
+}
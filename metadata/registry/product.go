@@ -2,6 +2,7 @@ package registry
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"github.com/viant/sqlx/metadata/database"
 	"reflect"
 	"strings"
@@ -11,7 +12,7 @@ const defaultProductName = "ansi"
 
 //MatchProduct matches product with sql driver
 func MatchProduct(db *sql.DB) *database.Product {
-	driverTypeName := reflect.TypeOf(db.Driver()).Elem().String()
+	driverTypeName := reflect.TypeOf(unwrapDriver(db.Driver())).Elem().String()
 	driverTypePair := strings.Split(driverTypeName, ".")
 	driverPkg := driverTypePair[0]
 	driverName := driverTypePair[1]
@@ -20,7 +21,8 @@ func MatchProduct(db *sql.DB) *database.Product {
 		if strings.Contains(driverPkg, name) ||
 			(candidate.DriverPkg != "" && strings.Contains(driverPkg, candidate.DriverPkg)) ||
 			(candidate.Driver != "" && strings.Contains(candidate.Driver, driverName) && driverName != "Driver") { // CONDITION WAS MET FOR VERTICA AND BIGQUERY WHEN driverName == "Driver"
-			product = candidate
+			matched := *candidate //registered product is shared, detection updates driver and version
+			product = &matched
 			product.DriverPkg = driverPkg
 			product.Driver = driverName
 		}
@@ -33,3 +35,14 @@ func MatchProduct(db *sql.DB) *database.Product {
 	}
 	return product
 }
+
+//unwrapDriver returns underlying driver for wrapping drivers (i.e. read/write router)
+func unwrapDriver(aDriver driver.Driver) driver.Driver {
+	for {
+		wrapper, ok := aDriver.(interface{ Unwrap() driver.Driver })
+		if !ok {
+			return aDriver
+		}
+		aDriver = wrapper.Unwrap()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx/io/hook"
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/product/ansi"
@@ -93,7 +94,8 @@ func (s *Service) Info(ctx context.Context, db *sql.DB, kind info.Kind, sink Sin
 		return fmt.Errorf("unsupported kind: %s, for: %sv%v", kind, product.Name, product.Major)
 	}
 
-	done, err := s.runHandler(ctx, db, query.PreHandlers, sink, options)
+	handlerOptions := append(option.Options{product}, options...) //handlers can check detected product version
+	done, err := s.runHandler(ctx, db, query.PreHandlers, sink, handlerOptions)
 	if done || err != nil {
 		return err
 	}
@@ -102,7 +104,7 @@ func (s *Service) Info(ctx context.Context, db *sql.DB, kind info.Kind, sink Sin
 		return err
 	}
 
-	done, err = s.runHandler(ctx, db, query.PostHandlers, sink, options)
+	done, err = s.runHandler(ctx, db, query.PostHandlers, sink, handlerOptions)
 	if done || err != nil {
 		return err
 	}
@@ -186,7 +188,14 @@ func (s *Service) executeQuery(ctx context.Context, db *sql.DB, query *info.Quer
 		return nil, err
 	}
 	defer stmt.Close()
-	return stmt.ExecContext(ctx, params...)
+	ctx, done := option.Options(options).Hooks().Start(ctx, &hook.Event{Op: hook.OpExec, Source: "metadata", SQL: SQL, Args: params})
+	result, err := stmt.ExecContext(ctx, params...)
+	var affected int64
+	if err == nil {
+		affected, _ = result.RowsAffected()
+	}
+	done(affected, err)
+	return result, err
 }
 
 func (s *Service) runQuery(ctx context.Context, db *sql.DB, query *info.Query, sink Sink, options ...option.Option) error {
@@ -218,11 +227,13 @@ func (s *Service) runQuery(ctx context.Context, db *sql.DB, query *info.Query, s
 	defer stmt.Close()
 
 	var rows *sql.Rows
+	_, done := option.Options(options).Hooks().Start(ctx, &hook.Event{Op: hook.OpQuery, Source: "metadata", SQL: SQL, Args: params})
 	if len(params) > 0 {
 		rows, err = stmt.Query(params...)
 	} else {
 		rows, err = stmt.Query()
 	}
+	done(0, err)
 	if err != nil {
 		return err
 	}
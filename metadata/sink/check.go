@@ -0,0 +1,10 @@
+package sink
+
+//Check represents table check constraint
+type Check struct {
+	Name    string `sqlx:"CONSTRAINT_NAME"`
+	Catalog string `sqlx:"CONSTRAINT_CATALOG"`
+	Schema  string `sqlx:"CONSTRAINT_SCHEMA"`
+	Table   string `sqlx:"TABLE_NAME"`
+	Clause  string `sqlx:"CHECK_CLAUSE"`
+}
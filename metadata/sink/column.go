@@ -15,6 +15,7 @@ type Column struct {
 	Position        int          `sqlx:"ORDINAL_POSITION"`
 	Comments        string       `sqlx:"COLUMN_COMMENT"`
 	Type            string       `sqlx:"DATA_TYPE"`
+	ColumnType      string       `sqlx:"COLUMN_TYPE"`
 	Length          *int64       `sqlx:"CHARACTER_MAXIMUM_LENGTH"`
 	Precision       *int64       `sqlx:"NUMERIC_PRECISION"`
 	Scale           *int64       `sqlx:"NUMERIC_SCALE"`
@@ -76,6 +77,41 @@ func (s *Column) Autoincrement() bool {
 	return strings.Contains(text, "autoincrement") || strings.Contains(text, "auto_increment")
 }
 
+// EnumValues returns enum or set allowed values parsed from column type i.e. enum('a','b'), isSet is true for set column
+func (c *Column) EnumValues() (values []string, isSet bool) {
+	definition := strings.TrimSpace(c.ColumnType)
+	lcDefinition := strings.ToLower(definition)
+	switch {
+	case strings.HasPrefix(lcDefinition, "enum("):
+		definition = definition[5:]
+	case strings.HasPrefix(lcDefinition, "set("):
+		definition = definition[4:]
+		isSet = true
+	default:
+		return nil, false
+	}
+	definition = strings.TrimSuffix(definition, ")")
+	for i := 0; i < len(definition); i++ {
+		if definition[i] != '\'' {
+			continue
+		}
+		value := strings.Builder{}
+		for i++; i < len(definition); i++ {
+			if definition[i] == '\'' {
+				if i+1 < len(definition) && definition[i+1] == '\'' { //escaped quote
+					value.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+			value.WriteByte(definition[i])
+		}
+		values = append(values, value.String())
+	}
+	return values, isSet
+}
+
 type Columns []Column
 
 type columnName string
@@ -0,0 +1,27 @@
+package sink
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestColumn_EnumValues(t *testing.T) {
+	var testCases = []struct {
+		description string
+		columnType  string
+		expected    []string
+		isSet       bool
+	}{
+		{description: "enum", columnType: "enum('a','b')", expected: []string{"a", "b"}},
+		{description: "set", columnType: "SET('x','y z')", expected: []string{"x", "y z"}, isSet: true},
+		{description: "escaped quote", columnType: "enum('it''s','a,b')", expected: []string{"it's", "a,b"}},
+		{description: "not enum", columnType: "varchar(20)"},
+	}
+
+	for _, testCase := range testCases {
+		column := &Column{ColumnType: testCase.columnType}
+		values, isSet := column.EnumValues()
+		assert.EqualValues(t, testCase.expected, values, testCase.description)
+		assert.EqualValues(t, testCase.isSet, isSet, testCase.description)
+	}
+}
@@ -0,0 +1,7 @@
+package sink
+
+//Replication represents replica replication status
+type Replication struct {
+	LagSeconds float64 `sqlx:"LAG_SECONDS"`
+	Stopped    bool    `sqlx:"STOPPED"` //replication IO or SQL thread is not running
+}
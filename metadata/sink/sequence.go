@@ -54,6 +54,16 @@ func (s *Sequence) NextValue(recordCount int64) int64 {
 	return s.Value - modValue + s.IncrementBy + recordCount*s.IncrementBy
 }
 
+// SetRange sets sequence value right after reserved values range starting with first value,
+// so that MinValue(count) returns first reserved value
+func (s *Sequence) SetRange(first, count int64) {
+	if s.IncrementBy == 0 {
+		s.IncrementBy = 1
+	}
+	s.StartValue = first
+	s.Value = first + count*s.IncrementBy
+}
+
 func (s *Sequence) ComputeNextForTransient(recordCount int64) (int64, error) {
 	if recordCount <= 0 {
 		return 0, fmt.Errorf("recordCount must be > 0, got %d", recordCount)
@@ -291,3 +291,41 @@ func TestSequence_MinValue(t *testing.T) {
 		assert.EqualValues(t, testCase.expect, actual, testCase.description)
 	}
 }
+
+func TestSequence_SetRange(t *testing.T) {
+	var testCases = []struct {
+		description string
+		seq         *Sequence
+		first       int64
+		records     int64
+		expectValue int64
+	}{
+		{
+			description: "single value",
+			seq:         &Sequence{IncrementBy: 1},
+			first:       101,
+			records:     1,
+			expectValue: 102,
+		},
+		{
+			description: "range with increment",
+			seq:         &Sequence{IncrementBy: 5},
+			first:       20,
+			records:     4, //20 -> 25 -> 30 -> 35
+			expectValue: 40,
+		},
+		{
+			description: "undefined increment",
+			seq:         &Sequence{},
+			first:       7,
+			records:     3,
+			expectValue: 10,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase.seq.SetRange(testCase.first, testCase.records)
+		assert.EqualValues(t, testCase.expectValue, testCase.seq.Value, testCase.description)
+		assert.EqualValues(t, testCase.first, testCase.seq.MinValue(testCase.records), testCase.description)
+	}
+}
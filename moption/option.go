@@ -2,6 +2,8 @@ package moption
 
 import (
 	"database/sql"
+	"github.com/viant/sqlx/io/audit"
+	"github.com/viant/sqlx/io/retry"
 	"github.com/viant/sqlx/loption"
 	"github.com/viant/sqlx/option"
 )
@@ -11,6 +13,8 @@ type (
 		tx            *sql.Tx
 		loadOptions   []loption.Option
 		commonOptions option.Options
+		retryPolicy   *retry.Policy
+		auditTrail    *audit.Trail
 	}
 
 	Option func(o *Options)
@@ -36,6 +40,21 @@ func WithLoadOptions(loadOptionSlice []loption.Option) Option {
 	}
 }
 
+// WithRetryPolicy sets retry policy re-running service owned transaction on transient failure,
+// policy is ignored when transaction was supplied with WithTransaction or common options
+func WithRetryPolicy(policy *retry.Policy) Option {
+	return func(o *Options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithAuditTrail sets audit trail capturing merged rows images by record identity
+func WithAuditTrail(trail *audit.Trail) Option {
+	return func(o *Options) {
+		o.auditTrail = trail
+	}
+}
+
 func WithCommonOptions(commonOptions option.Options) Option {
 	return func(o *Options) {
 		o.commonOptions = commonOptions
@@ -62,3 +81,24 @@ func (o *Options) GetLoadOptions() []loption.Option {
 func (o *Options) GetCommonOptions() option.Options {
 	return o.commonOptions
 }
+
+// GetRetryPolicy returns retry policy for service owned transaction, or nil if transaction was supplied
+func (o *Options) GetRetryPolicy() *retry.Policy {
+	if o.tx != nil || o.commonOptions.Tx() != nil {
+		return nil
+	}
+	if o.retryPolicy != nil {
+		return o.retryPolicy
+	}
+	return retry.Owned(o.commonOptions)
+}
+
+// GetAuditTrail returns audit trail or nil
+func (o *Options) GetAuditTrail() *audit.Trail {
+	if o.auditTrail != nil {
+		return o.auditTrail
+	}
+	var trail *audit.Trail
+	option.Assign(o.commonOptions, &trail)
+	return trail
+}
@@ -0,0 +1,45 @@
+package option
+
+import (
+	"github.com/viant/sqlx/io/hook"
+	"github.com/viant/sqlx/io/metrics"
+)
+
+// Hooks returns SQL hooks chain built from supplied hook.Hook, metrics.Collector and hook.Redactor options or nil
+func (o Options) Hooks() *hook.Chain {
+	var hooks []hook.Hook
+	var redactor hook.Redactor
+	for _, candidate := range o {
+		switch actual := candidate.(type) {
+		case hook.Redactor:
+			redactor = actual
+		case hook.Hook:
+			if actual != nil {
+				hooks = append(hooks, actual)
+			}
+		case metrics.Collector:
+			if actual != nil {
+				hooks = append(hooks, metrics.NewHook(actual))
+			}
+		}
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	if len(hooks) == 1 && redactor == nil {
+		if chain, ok := hooks[0].(*hook.Chain); ok {
+			return chain
+		}
+	}
+	return hook.New(hooks...).WithRedactor(redactor)
+}
+
+// Collector returns metrics collector or nil
+func (o Options) Collector() metrics.Collector {
+	for _, candidate := range o {
+		if collector, ok := candidate.(metrics.Collector); ok {
+			return collector
+		}
+	}
+	return nil
+}
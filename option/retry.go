@@ -0,0 +1,26 @@
+package option
+
+type (
+	// RetryPolicy represents write service retry policy option marker, see retry.Policy in io/retry package
+	RetryPolicy interface {
+		IsRetryPolicy()
+	}
+)
+
+// RetryPolicy returns retry policy or nil
+func (o Options) RetryPolicy() RetryPolicy {
+	for _, candidate := range o {
+		if policy, ok := candidate.(RetryPolicy); ok {
+			return policy
+		}
+	}
+	return nil
+}
+
+// OwnedRetryPolicy returns retry policy for service owned transaction, or nil if caller supplied global *sql.Tx
+func (o Options) OwnedRetryPolicy() RetryPolicy {
+	if o.Tx() != nil {
+		return nil
+	}
+	return o.RetryPolicy()
+}
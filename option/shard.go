@@ -0,0 +1,37 @@
+package option
+
+import "database/sql"
+
+type (
+	// Shard represents database shard, Suffix is appended to service table name
+	Shard struct {
+		DB     *sql.DB
+		Suffix string
+	}
+
+	// ShardResolver returns shard for record
+	ShardResolver func(record interface{}) (*Shard, error)
+)
+
+// ShardResolver returns shard resolver or nil
+func (o Options) ShardResolver() ShardResolver {
+	for _, candidate := range o {
+		if resolver, ok := candidate.(ShardResolver); ok && resolver != nil {
+			return resolver
+		}
+	}
+	return nil
+}
+
+// Unsharded returns options without shard resolver and *sql.DB override, used to execute single shard records
+func (o Options) Unsharded() Options {
+	var result = make(Options, 0, len(o))
+	for _, candidate := range o {
+		switch candidate.(type) {
+		case ShardResolver, *sql.DB:
+			continue
+		}
+		result = append(result, candidate)
+	}
+	return result
+}